@@ -9,6 +9,8 @@ import (
 type Node interface {
 	TokenLiteral() string
 	String() string
+	Pos() tokens.Position // position of the first byte of the node in the source
+	End() tokens.Position // position right after the node
 }
 
 type Statement interface {
@@ -23,7 +25,8 @@ type Expression interface {
 
 type ValueNode[T any] struct {
 	tokens.Token
-	V T
+	V    T
+	Span tokens.Span
 }
 
 func (vn ValueNode[T]) Value() T {
@@ -38,10 +41,22 @@ func (vn *ValueNode[T]) SetToken(token tokens.Token) {
 	vn.Token = token
 }
 
+func (vn *ValueNode[T]) SetSpan(span tokens.Span) {
+	vn.Span = span
+}
+
 func (vn ValueNode[T]) TokenLiteral() string {
 	return vn.Token.Literal()
 }
 
+func (vn ValueNode[T]) Pos() tokens.Position {
+	return vn.Span.Start
+}
+
+func (vn ValueNode[T]) End() tokens.Position {
+	return vn.Span.End
+}
+
 type Program struct {
 	Statements []Statement
 }
@@ -54,9 +69,26 @@ func (p Program) TokenLiteral() string {
 	return ""
 }
 
+func (p Program) Pos() tokens.Position {
+	if len(p.Statements) > 0 {
+		return p.Statements[0].Pos()
+	}
+
+	return tokens.Position{}
+}
+
+func (p Program) End() tokens.Position {
+	if len(p.Statements) > 0 {
+		return p.Statements[len(p.Statements)-1].End()
+	}
+
+	return tokens.Position{}
+}
+
 type TokenValuer[T any] interface {
 	SetValue(value T)
 	SetToken(token tokens.Token)
+	SetSpan(span tokens.Span)
 }
 
 func NewValueNode[T any, V any, PT interface {
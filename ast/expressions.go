@@ -30,6 +30,14 @@ func (i IntegerExpression) String() string {
 	return i.Token.Literal()
 }
 
+type FloatExpression struct {
+	ExpressionNode[float64]
+}
+
+func (i FloatExpression) String() string {
+	return i.Token.Literal()
+}
+
 type PrefixExpression struct {
 	ExpressionNode[Expression]
 	Operator string
@@ -149,3 +157,83 @@ type StringExpression struct {
 func (p StringExpression) String() string {
 	return fmt.Sprintf(`"%s"`, p.TokenLiteral())
 }
+
+type MemberExpression struct {
+	ExpressionNode[Expression] // Value is the object
+	Name                       *IdentifierExpression
+}
+
+func (p MemberExpression) String() string {
+	return p.Value().String() + "." + p.Name.String()
+}
+
+type ArrayExpression struct {
+	ExpressionNode[[]Expression] // Value is the elements
+}
+
+func (p ArrayExpression) String() string {
+	elements := lo.Map(p.Value(), func(item Expression, _ int) string {
+		return item.String()
+	})
+
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+type IndexExpression struct {
+	ExpressionNode[Expression] // Value is the indexed expression
+	Index                      Expression
+}
+
+func (p IndexExpression) String() string {
+	return fmt.Sprintf("(%s[%s])", p.Value().String(), p.Index.String())
+}
+
+type HashPair struct {
+	Key   Expression
+	Value Expression
+}
+
+type HashExpression struct {
+	ExpressionNode[[]HashPair] // Value is the pairs in source order
+}
+
+func (p HashExpression) String() string {
+	pairs := lo.Map(p.Value(), func(pair HashPair, _ int) string {
+		return pair.Key.String() + ": " + pair.Value.String()
+	})
+
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+type TryExpression struct {
+	ExpressionNode[*BlockStatement]                       // Value is the try block
+	Param                           *IdentifierExpression // binding of the caught error, nil without catch
+	Catch                           *BlockStatement
+	Finally                         *BlockStatement
+}
+
+func (p TryExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("try" + braced(p.Value()))
+
+	if p.Catch != nil {
+		out.WriteString(" catch (" + p.Param.String() + ")" + braced(p.Catch))
+	}
+
+	if p.Finally != nil {
+		out.WriteString(" finally" + braced(p.Finally))
+	}
+
+	return out.String()
+}
+
+// braced returns the block in braces preceded by a space.
+func braced(block *BlockStatement) string {
+	body := block.String()
+	if len(body) == 0 {
+		return " { }"
+	}
+
+	return " { " + body + " }"
+}
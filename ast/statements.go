@@ -2,6 +2,10 @@ package ast
 
 import (
 	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
 )
 
 type StatementNode[T any] struct {
@@ -73,3 +77,37 @@ func (p BlockStatement) String() string {
 
 	return out.String()
 }
+
+type ImportStatement struct {
+	StatementNode[*StringExpression]                         // Value is the module path
+	Name                             *IdentifierExpression   // binding of the whole module named after the path, nil when Names are imported
+	Names                            []*IdentifierExpression // exported names bound in the importing module
+}
+
+func (i ImportStatement) String() string {
+	if i.Name != nil {
+		return fmt.Sprintf("import %s;", i.V.String())
+	}
+
+	names := lo.Map(i.Names, func(name *IdentifierExpression, _ int) string {
+		return name.String()
+	})
+
+	return fmt.Sprintf("import { %s } from %s;", strings.Join(names, ", "), i.V.String())
+}
+
+type ExportStatement struct {
+	StatementNode[*LetStatement]
+}
+
+func (e ExportStatement) String() string {
+	return "export " + e.V.String()
+}
+
+type ThrowStatement struct {
+	StatementNode[Expression]
+}
+
+func (t ThrowStatement) String() string {
+	return t.TokenLiteral() + " " + t.Value().String() + ";"
+}
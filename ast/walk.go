@@ -0,0 +1,194 @@
+package ast
+
+import "reflect"
+
+// Visitor's Visit method is invoked for each node encountered by Walk. If the result visitor w is not nil,
+// Walk visits each of the children of node with the visitor w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk traverses the tree in depth-first order: it starts by calling v.Visit(node),
+// children are visited in source order, nil children are skipped.
+func Walk(node Node, visitor Visitor) { //nolint:cyclop,funlen
+	if visitor = visitor.Visit(node); visitor == nil {
+		return
+	}
+
+	switch node := node.(type) {
+	case *Program:
+		walkList(node.Statements, visitor)
+	case *LetStatement:
+		walkNode(node.Name, visitor)
+		walkNode(node.V, visitor)
+	case *ReturnStatement:
+		walkNode(node.V, visitor)
+	case *ThrowStatement:
+		walkNode(node.V, visitor)
+	case *ExpressionStatement:
+		walkNode(node.V, visitor)
+	case *BlockStatement:
+		walkList(node.V, visitor)
+	case *PrefixExpression:
+		walkNode(node.V, visitor)
+	case *InfixExpression:
+		walkNode(node.V, visitor)
+		walkNode(node.Right, visitor)
+	case *IfExpression:
+		walkNode(node.V, visitor)
+		walkNode(node.Then, visitor)
+		walkNode(node.Else, visitor)
+	case *TryExpression:
+		walkNode(node.V, visitor)
+		walkNode(node.Param, visitor)
+		walkNode(node.Catch, visitor)
+		walkNode(node.Finally, visitor)
+	case *FunctionExpression:
+		walkList(node.Arguments, visitor)
+		walkNode(node.V, visitor)
+	case *CallExpression:
+		walkNode(node.V, visitor)
+		walkList(node.Arguments, visitor)
+	case *MemberExpression:
+		walkNode(node.V, visitor)
+		walkNode(node.Name, visitor)
+	case *ArrayExpression:
+		walkList(node.V, visitor)
+	case *HashExpression:
+		for _, pair := range node.V {
+			walkNode(pair.Key, visitor)
+			walkNode(pair.Value, visitor)
+		}
+	case *IndexExpression:
+		walkNode(node.V, visitor)
+		walkNode(node.Index, visitor)
+	case *ImportStatement:
+		walkNode(node.Name, visitor)
+		walkList(node.Names, visitor)
+		walkNode(node.V, visitor)
+	case *ExportStatement:
+		walkNode(node.V, visitor)
+	}
+
+	visitor.Visit(nil)
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+
+	return nil
+}
+
+// Inspect traverses the tree in depth-first order calling fn for each node, if fn returns true,
+// Inspect continues with the children of the node, followed by a call of fn(nil).
+func Inspect(node Node, fn func(Node) bool) {
+	Walk(node, inspector(fn))
+}
+
+// Modify replaces nodes of the tree bottom-up: children are modified first, then the node itself is passed
+// to the modifier and its result is returned. A replacement which does not fit the parent's field type,
+// for instance an expression returned for a statement, is ignored and the original child is kept.
+func Modify(node Node, modifier func(Node) Node) Node { //nolint:cyclop,funlen
+	switch node := node.(type) {
+	case *Program:
+		node.Statements = modifyList(node.Statements, modifier)
+	case *LetStatement:
+		node.Name = modifyNode(node.Name, modifier)
+		node.V = modifyNode(node.V, modifier)
+	case *ReturnStatement:
+		node.V = modifyNode(node.V, modifier)
+	case *ThrowStatement:
+		node.V = modifyNode(node.V, modifier)
+	case *ExpressionStatement:
+		node.V = modifyNode(node.V, modifier)
+	case *BlockStatement:
+		node.V = modifyList(node.V, modifier)
+	case *PrefixExpression:
+		node.V = modifyNode(node.V, modifier)
+	case *InfixExpression:
+		node.V = modifyNode(node.V, modifier)
+		node.Right = modifyNode(node.Right, modifier)
+	case *IfExpression:
+		node.V = modifyNode(node.V, modifier)
+		node.Then = modifyNode(node.Then, modifier)
+		node.Else = modifyNode(node.Else, modifier)
+	case *TryExpression:
+		node.V = modifyNode(node.V, modifier)
+		node.Param = modifyNode(node.Param, modifier)
+		node.Catch = modifyNode(node.Catch, modifier)
+		node.Finally = modifyNode(node.Finally, modifier)
+	case *FunctionExpression:
+		node.Arguments = modifyList(node.Arguments, modifier)
+		node.V = modifyNode(node.V, modifier)
+	case *CallExpression:
+		node.V = modifyNode(node.V, modifier)
+		node.Arguments = modifyList(node.Arguments, modifier)
+	case *MemberExpression:
+		node.V = modifyNode(node.V, modifier)
+		node.Name = modifyNode(node.Name, modifier)
+	case *ArrayExpression:
+		node.V = modifyList(node.V, modifier)
+	case *HashExpression:
+		for i, pair := range node.V {
+			node.V[i] = HashPair{Key: modifyNode(pair.Key, modifier), Value: modifyNode(pair.Value, modifier)}
+		}
+	case *IndexExpression:
+		node.V = modifyNode(node.V, modifier)
+		node.Index = modifyNode(node.Index, modifier)
+	case *ImportStatement:
+		node.Name = modifyNode(node.Name, modifier)
+		node.Names = modifyList(node.Names, modifier)
+		node.V = modifyNode(node.V, modifier)
+	case *ExportStatement:
+		node.V = modifyNode(node.V, modifier)
+	}
+
+	return modifier(node)
+}
+
+func walkNode[T Node](node T, visitor Visitor) {
+	if !isNil(node) {
+		Walk(node, visitor)
+	}
+}
+
+func walkList[T Node](nodes []T, visitor Visitor) {
+	for _, node := range nodes {
+		walkNode(node, visitor)
+	}
+}
+
+func modifyNode[T Node](node T, modifier func(Node) Node) T {
+	if isNil(node) {
+		return node
+	}
+
+	if modified, ok := Modify(node, modifier).(T); ok && !isNil(modified) {
+		return modified
+	}
+
+	return node
+}
+
+func modifyList[T Node](nodes []T, modifier func(Node) Node) []T {
+	for i, node := range nodes {
+		nodes[i] = modifyNode(node, modifier)
+	}
+
+	return nodes
+}
+
+// isNil reports whether the node is nil or a nil pointer stored in the interface, like a missing else block.
+func isNil(node Node) bool {
+	if node == nil {
+		return true
+	}
+
+	value := reflect.ValueOf(node)
+
+	return value.Kind() == reflect.Pointer && value.IsNil()
+}
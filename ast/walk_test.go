@@ -0,0 +1,159 @@
+package ast_test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/tokens"
+)
+
+func parse(input string) *ast.Program {
+	return lo.Must(parser.New(lexer.New(input)).ParseProgram())
+}
+
+func typeName(node ast.Node) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", node), "*ast.")
+}
+
+type depthVisitor struct {
+	depth int
+	lines *[]string
+}
+
+func (v depthVisitor) Visit(node ast.Node) ast.Visitor {
+	if node == nil {
+		return nil
+	}
+
+	*v.lines = append(*v.lines, strings.Repeat(" ", v.depth)+typeName(node))
+
+	if _, ok := node.(*ast.FunctionExpression); ok {
+		return nil
+	}
+
+	return depthVisitor{depth: v.depth + 1, lines: v.lines}
+}
+
+var _ = Describe("Walk", func() {
+	It("visits every node in source order", func() {
+		program := parse(`let a = -1; return if (a < b) { f(a, "s") } else { nil }; fn(x) { true }`)
+
+		visited := []string{}
+
+		ast.Inspect(program, func(node ast.Node) bool {
+			if node != nil {
+				visited = append(visited, typeName(node)+" "+node.String())
+			}
+
+			return true
+		})
+
+		Expect(visited).To(Equal([]string{
+			`Program let a = (-1);return if (a < b) { f(a, "s") } else { nil };fn(x) { true }`,
+			"LetStatement let a = (-1);",
+			"IdentifierExpression a",
+			"PrefixExpression (-1)",
+			"IntegerExpression 1",
+			`ReturnStatement return if (a < b) { f(a, "s") } else { nil };`,
+			`IfExpression if (a < b) { f(a, "s") } else { nil }`,
+			"InfixExpression (a < b)",
+			"IdentifierExpression a",
+			"IdentifierExpression b",
+			`BlockStatement f(a, "s")`,
+			`ExpressionStatement f(a, "s")`,
+			`CallExpression f(a, "s")`,
+			"IdentifierExpression f",
+			"IdentifierExpression a",
+			`StringExpression "s"`,
+			"BlockStatement nil",
+			"ExpressionStatement nil",
+			"NilExpression nil",
+			"ExpressionStatement fn(x) { true }",
+			"FunctionExpression fn(x) { true }",
+			"IdentifierExpression x",
+			"BlockStatement true",
+			"ExpressionStatement true",
+			"BooleanExpression true",
+		}))
+	})
+
+	It("skips children when the visitor returns nil", func() {
+		lines := []string{}
+
+		ast.Walk(parse("if (true) { fn(x) { x } }"), depthVisitor{lines: &lines})
+
+		Expect(lines).To(Equal([]string{
+			"Program",
+			" ExpressionStatement",
+			"  IfExpression",
+			"   BooleanExpression",
+			"   BlockStatement",
+			"    ExpressionStatement",
+			"     FunctionExpression",
+		}))
+	})
+
+	It("visits blocks of try expressions", func() {
+		lines := []string{}
+
+		ast.Walk(parse("try { a } catch (e) { throw e } finally { }"), depthVisitor{lines: &lines})
+
+		Expect(lines).To(Equal([]string{
+			"Program",
+			" ExpressionStatement",
+			"  TryExpression",
+			"   BlockStatement",
+			"    ExpressionStatement",
+			"     IdentifierExpression",
+			"   IdentifierExpression",
+			"   BlockStatement",
+			"    ThrowStatement",
+			"     IdentifierExpression",
+			"   BlockStatement",
+		}))
+	})
+})
+
+var _ = Describe("Modify", func() {
+	It("replaces nodes everywhere in the tree", func() {
+		program := parse("let f = fn(a) { if (a) { g(1, a) } else { 1 } }; f(1)")
+
+		modified := ast.Modify(program, func(node ast.Node) ast.Node {
+			switch node := node.(type) {
+			case *ast.IntegerExpression:
+				token := tokens.New(tokens.INTEGER, strconv.FormatInt(node.V+1, 10))
+
+				return ast.NewValueNode[ast.IntegerExpression](token, node.V+1)
+			case *ast.IdentifierExpression:
+				if node.V == "a" {
+					return ast.NewValueNode[ast.IdentifierExpression](node.Token, "b")
+				}
+			}
+
+			return node
+		})
+
+		Expect(modified.String()).To(Equal("let f = fn(b) { if b { g(2, b) } else { 2 } };f(2)"))
+	})
+
+	It("keeps the original node when the replacement does not fit", func() {
+		program := parse("let a = 1;")
+
+		modified := ast.Modify(program, func(node ast.Node) ast.Node {
+			if _, ok := node.(*ast.IdentifierExpression); ok {
+				return parse("2").Statements[0]
+			}
+
+			return node
+		})
+
+		Expect(modified.String()).To(Equal("let a = 1;"))
+	})
+})
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+	"github.com/zhulik/monkey/dap"
+)
+
+func debugCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "debug",
+		Usage: "run the debug adapter over stdin and stdout",
+		Action: func(_ *cli.Context) error {
+			err := dap.NewServer(os.Stdin, os.Stdout).Serve()
+			if err != nil {
+				return fmt.Errorf("debug adapter error: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v2"
+	"github.com/zhulik/monkey/format"
+)
+
+var errWriteStdin = errors.New("cannot use -w with standard input")
+
+func fmtCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "fmt",
+		Usage:     "format Monkey source files, standard input is formatted when no files given",
+		ArgsUsage: "[files...]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "w", Usage: "write result to the source file instead of stdout"},
+		},
+		Action: func(ctx *cli.Context) error {
+			write := ctx.Bool("w")
+
+			if ctx.NArg() == 0 {
+				if write {
+					return errWriteStdin
+				}
+
+				return formatReader(os.Stdin, ctx.App.Writer)
+			}
+
+			for _, file := range ctx.Args().Slice() {
+				err := formatFile(file, write, ctx.App.Writer)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func formatReader(in io.Reader, out io.Writer) error {
+	src, err := io.ReadAll(in)
+	if err != nil {
+		return fmt.Errorf("reading input error: %w", err)
+	}
+
+	result, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("<stdin>: %w", err)
+	}
+
+	_, err = out.Write(result)
+
+	return err //nolint:wrapcheck
+}
+
+func formatFile(file string, write bool, out io.Writer) error {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("reading file error: %w", err)
+	}
+
+	result, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	if !write {
+		_, err = out.Write(result)
+
+		return err //nolint:wrapcheck
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return fmt.Errorf("reading file error: %w", err)
+	}
+
+	err = os.WriteFile(file, result, info.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("writing file error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+	"github.com/zhulik/monkey/lsp"
+)
+
+func lspCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "lsp",
+		Usage: "run the language server over stdin and stdout",
+		Action: func(_ *cli.Context) error {
+			err := lsp.NewServer(os.Stdin, os.Stdout).Serve()
+			if err != nil {
+				return fmt.Errorf("language server error: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
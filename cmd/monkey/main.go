@@ -13,6 +13,15 @@ func main() {
 	app := cli.App{
 		Name:  "monkey",
 		Usage: "Monkey interpreter",
+		Commands: []*cli.Command{
+			fmtCommand(),
+			vetCommand(),
+			lspCommand(),
+			debugCommand(),
+			testCommand(),
+		},
+		ArgsUsage: "[script]",
+		Flags:     runFlags(),
 		Action: func(ctx *cli.Context) error {
 			file := ctx.Args().Get(0)
 			if file == "" {
@@ -20,9 +29,11 @@ func main() {
 				if err != nil {
 					return fmt.Errorf("repl error: %w", err)
 				}
+
+				return nil
 			}
 
-			return nil // read and execute file
+			return runFile(ctx, file)
 		},
 	}
 
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/module"
+	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/profiler"
+	"github.com/zhulik/monkey/stdlib"
+)
+
+var errOutsideRoot = errors.New("path is outside of the module root")
+
+func runFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{Name: "profile", Usage: "write a pprof profile of the script to `FILE`"},
+		&cli.StringFlag{Name: "folded", Usage: "write folded stacks of the script for flame graphs to `FILE`"},
+		&cli.StringSliceFlag{Name: "allow-fs", Usage: "allow the fs module to access files in `DIR`, may be repeated"},
+		moduleRootFlag("the directory of the script"),
+		modulePathFlag(),
+		seedFlag(),
+	}
+}
+
+func moduleRootFlag(defaultRoot string) cli.Flag {
+	return &cli.StringFlag{
+		Name:  "module-root",
+		Usage: "import modules only from `DIR` and its subdirectories, defaults to " + defaultRoot,
+	}
+}
+
+func modulePathFlag() cli.Flag {
+	return &cli.StringSliceFlag{
+		Name:    "module-path",
+		Usage:   "look up imported modules in `DIR` if they are not found next to the script, may be repeated",
+		EnvVars: []string{"MONKEY_PATH"},
+	}
+}
+
+func seedFlag() cli.Flag {
+	return &cli.Uint64Flag{Name: "seed", Usage: "seed random numbers with `N` to make runs reproducible"}
+}
+
+// hostFS returns the filesystem scripts are run from: the module root overlaid with directories of the module path,
+// modules can't be imported from other places of the disk.
+func hostFS(ctx *cli.Context, dir string) fs.FS {
+	layers := []fs.FS{os.DirFS(dir)}
+
+	for _, path := range ctx.StringSlice("module-path") {
+		layers = append(layers, os.DirFS(path))
+	}
+
+	return module.Overlay(layers...)
+}
+
+// hostPath returns the absolute path of the root and the name of the file in the filesystem rooted there, files
+// outside of the root are rejected.
+func hostPath(root, path string) (string, string, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return "", "", fmt.Errorf("resolving path error: %w", err)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", "", fmt.Errorf("resolving path error: %w", err)
+	}
+
+	name, err := filepath.Rel(root, abs)
+	if err != nil || !filepath.IsLocal(name) && name != "." {
+		return "", "", fmt.Errorf("%w: %s", errOutsideRoot, path)
+	}
+
+	return root, filepath.ToSlash(name), nil
+}
+
+// runFile evaluates the script and prints its result unless it's nil.
+func runFile(ctx *cli.Context, file string) error {
+	root := ctx.String("module-root")
+	if root == "" {
+		root = filepath.Dir(file)
+	}
+
+	root, name, err := hostPath(root, file)
+	if err != nil {
+		return err
+	}
+
+	fsys := hostFS(ctx, root)
+
+	src, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("reading file error: %w", err)
+	}
+
+	program, err := parser.New(lexer.New(string(src))).ParseProgram()
+	if err != nil {
+		return fmt.Errorf("%s: parsing error: %w", file, err)
+	}
+
+	opts := append(stdlib.Options(),
+		evaluator.WithFile(name),
+		evaluator.WithContext(ctx.Context),
+		module.New(fsys).Option(),
+	)
+
+	if roots := ctx.StringSlice("allow-fs"); len(roots) > 0 {
+		opts = append(opts, evaluator.WithFileRoots(roots...))
+	}
+
+	if ctx.IsSet("seed") {
+		seed := ctx.Uint64("seed")
+		opts = append(opts, evaluator.WithRandSource(rand.NewPCG(seed, seed)))
+	}
+
+	var prof *profiler.Profiler
+
+	if ctx.String("profile") != "" || ctx.String("folded") != "" {
+		prof = profiler.New(profiler.WithFilename(file))
+		opts = append(opts, prof.Option())
+	}
+
+	result, evalErr := evaluator.New(opts...).Eval(program)
+
+	if prof != nil {
+		err = writeProfiles(ctx, prof)
+		if err != nil {
+			return err
+		}
+	}
+
+	if evalErr != nil {
+		return fmt.Errorf("%s: evaluation error: %w", file, evalErr)
+	}
+
+	if result != obj.NIL {
+		fmt.Fprintln(ctx.App.Writer, result.Inspect())
+	}
+
+	return nil
+}
+
+func writeProfiles(ctx *cli.Context, prof *profiler.Profiler) error {
+	err := writeFile(ctx.String("profile"), prof.WritePprof)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(ctx.String("folded"), prof.WriteFolded)
+}
+
+// writeFile creates the file and writes to it, nothing happens if the path is empty.
+func writeFile(path string, write func(io.Writer) error) error {
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating file error: %w", err)
+	}
+	defer file.Close()
+
+	return write(file)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = Describe("runFile", func() {
+	var dir string
+
+	run := func(file string, args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		app := &cli.App{
+			Name:   "monkey",
+			Flags:  runFlags(),
+			Writer: out,
+			Action: func(ctx *cli.Context) error {
+				return runFile(ctx, file)
+			},
+		}
+
+		err := app.Run(append([]string{"monkey"}, args...))
+
+		return out.String(), err
+	}
+
+	write := func(name, source string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(source), 0o600)).To(Succeed())
+
+		return path
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("prints the result", func() {
+		Expect(run(write("main.mk", "1 + 2"))).To(Equal("3\n"))
+	})
+
+	It("prints nothing for a script without statements", func() {
+		Expect(run(write("empty.mk", "// only a comment"))).To(BeEmpty())
+	})
+	It("imports modules from parent directories inside of the module root", func() {
+		write("lib/u.mk", "export let five = 5;")
+
+		out, err := run(write("app/main.mk", `import { five } from "../lib/u"; five`), "--module-root", dir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(Equal("5\n"))
+	})
+
+	It("rejects imports outside of the module root", func() {
+		write("lib/u.mk", "export let five = 5;")
+
+		_, err := run(write("app/main.mk", `import { five } from "../lib/u"; five`))
+		Expect(err).To(MatchError(ContainSubstring("module is outside of the filesystem: ../lib/u.mk")))
+	})
+
+	It("rejects scripts outside of the module root", func() {
+		_, err := run(write("main.mk", "1"), "--module-root", filepath.Join(dir, "app"))
+		Expect(err).To(MatchError(errOutsideRoot))
+	})
+
+	It("imports modules from the module path", func() {
+		write("lib/u.mk", "export let five = 5;")
+
+		out, err := run(write("app/main.mk", `import { five } from "u"; five`), "--module-path", filepath.Join(dir, "lib"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(Equal("5\n"))
+	})
+})
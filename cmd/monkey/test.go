@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"github.com/zhulik/monkey/coverage"
+	"github.com/zhulik/monkey/tester"
+)
+
+const testFileSuffix = "_test.mk"
+
+func testCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "test",
+		Usage:     "run tests from *_test.mk files found in the given files and directories, the current directory by default",
+		ArgsUsage: "[paths...]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "run", Usage: "run only tests matching the `REGEXP`"},
+			&cli.IntFlag{Name: "parallel", Value: runtime.NumCPU(), Usage: "number of files to run at the same time"},
+			&cli.BoolFlag{Name: "v", Usage: "print every test with its duration"},
+			&cli.StringFlag{Name: "junit", Usage: "write a JUnit XML report to `FILE`"},
+			&cli.BoolFlag{Name: "cover", Usage: "print a line coverage summary"},
+			&cli.StringFlag{Name: "coverprofile", Usage: "write line coverage in the LCOV format to `FILE`"},
+			&cli.StringFlag{Name: "coverhtml", Usage: "write line coverage as an HTML page to `FILE`"},
+			moduleRootFlag("the current directory"),
+			modulePathFlag(),
+			seedFlag(),
+		},
+		Action: func(ctx *cli.Context) error {
+			root, err := filepath.Abs(ctx.String("module-root"))
+			if err != nil {
+				return fmt.Errorf("resolving path error: %w", err)
+			}
+
+			files, err := testFiles(root, ctx.Args().Slice())
+			if err != nil {
+				return err
+			}
+
+			wd, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("getting working directory error: %w", err)
+			}
+
+			opts := []tester.Option{
+				tester.WithParallelism(ctx.Int("parallel")),
+				tester.WithDisplayName(func(file string) string {
+					return localName(root, wd, file)
+				}),
+			}
+
+			if ctx.String("run") != "" {
+				filter, rErr := regexp.Compile(ctx.String("run"))
+				if rErr != nil {
+					return fmt.Errorf("invalid -run pattern: %w", rErr)
+				}
+
+				opts = append(opts, tester.WithFilter(filter))
+			}
+
+			if ctx.IsSet("seed") {
+				opts = append(opts, tester.WithSeed(ctx.Uint64("seed")))
+			}
+
+			var cov *coverage.Coverage
+
+			if ctx.Bool("cover") || ctx.String("coverprofile") != "" || ctx.String("coverhtml") != "" {
+				cov = coverage.New()
+				opts = append(opts, tester.WithCoverage(cov))
+			}
+
+			results := tester.New(hostFS(ctx, root), opts...).Run(files)
+
+			passed := true
+
+			for _, result := range results {
+				printResult(ctx.App.Writer, result, ctx.Bool("v"))
+
+				passed = passed && result.Passed()
+			}
+
+			err = writeFile(ctx.String("junit"), func(w io.Writer) error {
+				return tester.WriteJUnit(w, results)
+			})
+			if err != nil {
+				return err
+			}
+
+			if cov != nil {
+				err = writeCoverage(ctx, cov)
+				if err != nil {
+					return err
+				}
+			}
+
+			if !passed {
+				return cli.Exit("", 1)
+			}
+
+			return nil
+		},
+	}
+}
+
+// printResult prints failed tests, and passed ones too when verbose, followed by the file summary.
+func printResult(out io.Writer, result tester.FileResult, verbose bool) {
+	for _, test := range result.Tests {
+		switch {
+		case !test.Passed():
+			fmt.Fprintf(out, "--- FAIL: %s (%s)\n    %s\n", test.Name, round(test.Duration), test.Err)
+		case verbose:
+			fmt.Fprintf(out, "--- PASS: %s (%s)\n", test.Name, round(test.Duration))
+		}
+	}
+
+	switch {
+	case result.Err != nil:
+		fmt.Fprintf(out, "FAIL\t%s\t%s\n    %s\n", result.File, round(result.Duration), result.Err)
+	case !result.Passed():
+		fmt.Fprintf(out, "FAIL\t%s\t%s\n", result.File, round(result.Duration))
+	default:
+		fmt.Fprintf(out, "ok\t%s\t%s\n", result.File, round(result.Duration))
+	}
+}
+
+func round(duration time.Duration) time.Duration {
+	return duration.Round(time.Microsecond)
+}
+
+// testFiles returns test files from the paths, directories are searched recursively. Paths must be inside of
+// the root, files are named in the filesystem rooted there.
+func testFiles(root string, paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	files := []string{}
+	fsys := os.DirFS(root)
+
+	for _, path := range paths {
+		_, name, err := hostPath(root, path)
+		if err != nil {
+			return nil, err
+		}
+
+		err = fs.WalkDir(fsys, name, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !entry.IsDir() && (file == name || strings.HasSuffix(file, testFileSuffix)) {
+				files = append(files, file)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("searching test files error: %w", err)
+		}
+	}
+
+	return files, nil
+}
+
+// localName names the file of the filesystem rooted at the root relative to the working directory.
+func localName(root, wd, file string) string {
+	name, err := filepath.Rel(wd, filepath.Join(root, filepath.FromSlash(file)))
+	if err != nil {
+		return file
+	}
+
+	return filepath.ToSlash(name)
+}
+
+func writeCoverage(ctx *cli.Context, cov *coverage.Coverage) error {
+	if ctx.Bool("cover") {
+		err := cov.WriteText(ctx.App.Writer)
+		if err != nil {
+			return fmt.Errorf("writing coverage error: %w", err)
+		}
+	}
+
+	err := writeFile(ctx.String("coverprofile"), cov.WriteLCOV)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(ctx.String("coverhtml"), cov.WriteHTML)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = Describe("test command", func() {
+	var dir string
+
+	run := func(args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		app := &cli.App{Name: "monkey", Writer: out, Commands: []*cli.Command{testCommand()}}
+
+		err := app.Run(append([]string{"monkey", "test"}, args...))
+
+		return out.String(), err
+	}
+
+	write := func(name, source string) {
+		path := filepath.Join(dir, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(source), 0o600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("runs tests importing modules from parent directories inside of the module root", func() {
+		write("lib/u.mk", "export let five = 5;")
+		write("app/u_test.mk", `import { five } from "../lib/u"; test("five", fn() { assert_eq(five, 5) })`)
+
+		wd, err := os.Getwd()
+		Expect(err).ToNot(HaveOccurred())
+
+		name, err := filepath.Rel(wd, filepath.Join(dir, "app", "u_test.mk"))
+		Expect(err).ToNot(HaveOccurred())
+
+		out, err := run("--module-root", dir, filepath.Join(dir, "app"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(HavePrefix("ok\t" + filepath.ToSlash(name) + "\t"))
+	})
+
+	It("rejects paths outside of the module root", func() {
+		write("app/u_test.mk", `test("one", fn() { assert_eq(1, 1) })`)
+
+		_, err := run("--module-root", filepath.Join(dir, "lib"), filepath.Join(dir, "app"))
+		Expect(err).To(MatchError(errOutsideRoot))
+	})
+})
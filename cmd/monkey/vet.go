@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/urfave/cli/v2"
+	"github.com/zhulik/monkey/vet"
+)
+
+var errUnknownAnalyzer = errors.New("unknown analyzer")
+
+func vetCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "vet",
+		Usage:     "report suspicious constructs in Monkey source files, standard input is checked when no files given",
+		ArgsUsage: "[files...]",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{Name: "disable", Usage: "disable the analyzer, may be repeated"},
+			&cli.BoolFlag{Name: "list", Usage: "list available analyzers"},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.Bool("list") {
+				for _, analyzer := range vet.Analyzers() {
+					fmt.Fprintf(ctx.App.Writer, "%s\t%s\n", analyzer.Name, analyzer.Doc)
+				}
+
+				return nil
+			}
+
+			analyzers, err := enabledAnalyzers(ctx.StringSlice("disable"))
+			if err != nil {
+				return err
+			}
+
+			found := false
+
+			if ctx.NArg() == 0 {
+				found, err = vetReader("<stdin>", os.Stdin, ctx.App.Writer, analyzers)
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, file := range ctx.Args().Slice() {
+				fileFound, fErr := vetFile(file, ctx.App.Writer, analyzers)
+				if fErr != nil {
+					return fErr
+				}
+
+				found = found || fileFound
+			}
+
+			if found {
+				return cli.Exit("", 1)
+			}
+
+			return nil
+		},
+	}
+}
+
+func enabledAnalyzers(disabled []string) ([]*vet.Analyzer, error) {
+	analyzers := []*vet.Analyzer{}
+
+	for _, name := range disabled {
+		if _, ok := vet.Lookup(name); !ok {
+			return nil, fmt.Errorf("%w: %s", errUnknownAnalyzer, name)
+		}
+	}
+
+	for _, analyzer := range vet.Analyzers() {
+		if !slices.Contains(disabled, analyzer.Name) {
+			analyzers = append(analyzers, analyzer)
+		}
+	}
+
+	return analyzers, nil
+}
+
+func vetFile(file string, out io.Writer, analyzers []*vet.Analyzer) (bool, error) {
+	in, err := os.Open(file)
+	if err != nil {
+		return false, fmt.Errorf("reading file error: %w", err)
+	}
+	defer in.Close()
+
+	return vetReader(file, in, out, analyzers)
+}
+
+// vetReader prints diagnostics of the source and reports whether any were found.
+func vetReader(name string, in io.Reader, out io.Writer, analyzers []*vet.Analyzer) (bool, error) {
+	src, err := io.ReadAll(in)
+	if err != nil {
+		return false, fmt.Errorf("reading input error: %w", err)
+	}
+
+	diagnostics, err := vet.Source(src, analyzers...)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", name, err)
+	}
+
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintf(out, "%s:%s\n", name, diagnostic)
+	}
+
+	return len(diagnostics) > 0, nil
+}
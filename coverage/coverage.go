@@ -0,0 +1,193 @@
+// Package coverage records which statements and branches of Monkey programs were executed.
+package coverage
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/evaluator"
+)
+
+// Coverage counts executions of statements and branches of registered files, programs may be evaluated
+// concurrently.
+type Coverage struct {
+	mu sync.Mutex
+
+	files      map[string]*file
+	statements map[ast.Statement]*counter
+	branches   map[*ast.IfExpression]*branch
+}
+
+type file struct {
+	name       string
+	source     string
+	statements []*counter
+	branches   []*branch
+}
+
+type counter struct {
+	line  int
+	count int
+}
+
+type branch struct {
+	line int
+	then int
+	els  int
+}
+
+func New() *Coverage {
+	return &Coverage{
+		files:      map[string]*file{},
+		statements: map[ast.Statement]*counter{},
+		branches:   map[*ast.IfExpression]*branch{},
+	}
+}
+
+// Options return evaluator options which enable recording.
+func (c *Coverage) Options() []evaluator.Option {
+	return []evaluator.Option{
+		evaluator.WithHook(func(event evaluator.Event) error {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			if counter, ok := c.statements[event.Node]; ok {
+				counter.count++
+			}
+
+			return nil
+		}),
+		evaluator.WithBranchHook(func(node *ast.IfExpression, then bool) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			branch, ok := c.branches[node]
+			if !ok {
+				return
+			}
+
+			if then {
+				branch.then++
+			} else {
+				branch.els++
+			}
+		}),
+	}
+}
+
+// Add registers the program parsed from the file, only registered programs are covered. A file may be added
+// several times, for instance when several test files import it, executions of its programs are summed then.
+func (c *Coverage) Add(name, source string, program *ast.Program) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, known := c.files[name]
+	if !known || f.source != source {
+		f = &file{name: name, source: source}
+		c.files[name] = f
+		known = false
+	}
+
+	// Programs parsed from the same source have the same statements and branches in the same order.
+	statements, branches := 0, 0
+
+	ast.Inspect(program, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.BlockStatement:
+		case ast.Statement:
+			if !known {
+				f.statements = append(f.statements, &counter{line: node.Pos().Line})
+			}
+
+			c.statements[node] = f.statements[statements]
+			statements++
+		case *ast.IfExpression:
+			if !known {
+				f.branches = append(f.branches, &branch{line: node.Pos().Line})
+			}
+
+			c.branches[node] = f.branches[branches]
+			branches++
+		}
+
+		return true
+	})
+}
+
+// Branch is an if expression, Then and Else are numbers of times each branch was taken.
+type Branch struct {
+	Line int
+	Then int
+	Else int
+}
+
+// FileReport is line coverage of a file, a line is covered if any statement starting on it was executed.
+type FileReport struct {
+	Name     string
+	Source   string
+	Lines    map[int]int // executions by line, only lines where statements start are present
+	Branches []Branch
+}
+
+// Covered returns the number of executed lines.
+func (r FileReport) Covered() int {
+	covered := 0
+
+	for _, count := range r.Lines {
+		if count > 0 {
+			covered++
+		}
+	}
+
+	return covered
+}
+
+// BranchesCovered returns the number of taken branches, each if expression has two.
+func (r FileReport) BranchesCovered() int {
+	covered := 0
+
+	for _, branch := range r.Branches {
+		covered += min(branch.Then, 1) + min(branch.Else, 1)
+	}
+
+	return covered
+}
+
+// Percent returns the share of covered lines, a file without statements is fully covered.
+func (r FileReport) Percent() float64 {
+	if len(r.Lines) == 0 {
+		return 100 //nolint:mnd
+	}
+
+	return float64(r.Covered()) / float64(len(r.Lines)) * 100 //nolint:mnd
+}
+
+// Report returns coverage of registered files sorted by name.
+func (c *Coverage) Report() []FileReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	reports := make([]FileReport, 0, len(c.files))
+
+	for _, f := range c.files {
+		report := FileReport{Name: f.name, Source: f.source, Lines: map[int]int{}}
+
+		for _, counter := range f.statements {
+			report.Lines[counter.line] += counter.count
+		}
+
+		for _, branch := range f.branches {
+			report.Branches = append(report.Branches, Branch{Line: branch.line, Then: branch.then, Else: branch.els})
+		}
+
+		reports = append(reports, report)
+	}
+
+	slices.SortFunc(reports, func(a, b FileReport) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return reports
+}
@@ -0,0 +1,115 @@
+package coverage_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/coverage"
+	"github.com/zhulik/monkey/evaluator"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/parser"
+)
+
+const source = `let abs = fn(x) {
+  if (x < 0) {
+    return -x;
+  }
+  x
+};
+
+let sign = fn(x) {
+  if (x < 0) { -1 } else { 1 }
+};
+abs(5);
+sign(5);
+sign(6)`
+
+func run(source string) *coverage.Coverage {
+	program := lo.Must(parser.New(lexer.New(source)).ParseProgram())
+
+	cov := coverage.New()
+	cov.Add("abs.mk", source, program)
+
+	_, err := evaluator.New(cov.Options()...).Eval(program)
+	Expect(err).ToNot(HaveOccurred())
+
+	return cov
+}
+
+var _ = Describe("Coverage", func() {
+	It("records executed lines and branches", func() {
+		Expect(run(source).Report()).To(Equal([]coverage.FileReport{{
+			Name:   "abs.mk",
+			Source: source,
+			Lines:  map[int]int{1: 1, 2: 1, 3: 0, 5: 1, 8: 1, 9: 4, 11: 1, 12: 1, 13: 1},
+			Branches: []coverage.Branch{
+				{Line: 2, Then: 0, Else: 1},
+				{Line: 9, Then: 0, Else: 2},
+			},
+		}}))
+	})
+
+	It("sums executions of programs added for the same file", func() {
+		cov := coverage.New()
+
+		for range 2 {
+			program := lo.Must(parser.New(lexer.New(source)).ParseProgram())
+			cov.Add("abs.mk", source, program)
+
+			_, err := evaluator.New(cov.Options()...).Eval(program)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		report := cov.Report()
+		Expect(report).To(HaveLen(1))
+		Expect(report[0].Lines).To(HaveKeyWithValue(9, 8))
+		Expect(report[0].Branches).To(Equal([]coverage.Branch{{Line: 2, Then: 0, Else: 2}, {Line: 9, Then: 0, Else: 4}}))
+	})
+
+	It("ignores programs which were not added", func() {
+		cov := coverage.New()
+
+		_, err := evaluator.New(cov.Options()...).Eval(lo.Must(parser.New(lexer.New(source)).ParseProgram()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cov.Report()).To(BeEmpty())
+	})
+
+	Describe(".WriteText", func() {
+		It("writes a summary with uncovered lines", func() {
+			out := &bytes.Buffer{}
+			Expect(run(source).WriteText(out)).To(Succeed())
+			Expect(out.String()).To(Equal(
+				"file    lines  branches  coverage  uncovered\n" +
+					"abs.mk  8/9    2/4       88.9%     3\n",
+			))
+		})
+	})
+
+	Describe(".WriteLCOV", func() {
+		It("writes an LCOV tracefile", func() {
+			out := &bytes.Buffer{}
+			Expect(run(source).WriteLCOV(out)).To(Succeed())
+			Expect(out.String()).To(Equal("TN:\nSF:abs.mk\n" +
+				"BRDA:2,0,0,-\nBRDA:2,0,1,1\nBRDA:9,1,0,-\nBRDA:9,1,1,2\nBRF:4\nBRH:2\n" +
+				"DA:1,1\nDA:2,1\nDA:3,0\nDA:5,1\nDA:8,1\nDA:9,4\nDA:11,1\nDA:12,1\nDA:13,1\n" +
+				"LF:9\nLH:8\nend_of_record\n",
+			))
+		})
+	})
+
+	Describe(".WriteHTML", func() {
+		It("writes highlighted sources", func() {
+			out := &bytes.Buffer{}
+			Expect(run(source).WriteHTML(out)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring(
+				`<span class="line uncovered"><span class="number">3</span><span class="hits">0x</span>    return -x;</span>`,
+			))
+			Expect(out.String()).To(ContainSubstring(
+				`<span class="line covered"><span class="number">9</span><span class="hits">4x</span>`,
+			))
+			Expect(out.String()).To(ContainSubstring(`<span class="line "><span class="number">4</span><span class="hits"></span>  }</span>`))
+		})
+	})
+})
@@ -0,0 +1,170 @@
+package coverage
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/samber/lo"
+)
+
+// WriteText writes a per-file summary with uncovered line ranges.
+func (c *Coverage) WriteText(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(table, "file\tlines\tbranches\tcoverage\tuncovered")
+
+	for _, report := range c.Report() {
+		fmt.Fprintf(table, "%s\t%d/%d\t%d/%d\t%.1f%%\t%s\n",
+			report.Name, report.Covered(), len(report.Lines), report.BranchesCovered(), len(report.Branches)*2,
+			report.Percent(), uncoveredRanges(report))
+	}
+
+	return table.Flush() //nolint:wrapcheck
+}
+
+// uncoveredRanges formats instrumented lines which were not executed, consecutive instrumented lines are joined.
+func uncoveredRanges(report FileReport) string {
+	lines := lo.Keys(report.Lines)
+	slices.Sort(lines)
+
+	ranges := []string{}
+	start, end := 0, 0
+
+	flush := func() {
+		switch {
+		case start == 0:
+		case start == end:
+			ranges = append(ranges, fmt.Sprint(start))
+		default:
+			ranges = append(ranges, fmt.Sprintf("%d-%d", start, end))
+		}
+
+		start = 0
+	}
+
+	for _, line := range lines {
+		if report.Lines[line] > 0 {
+			flush()
+
+			continue
+		}
+
+		if start == 0 {
+			start = line
+		}
+
+		end = line
+	}
+
+	flush()
+
+	return strings.Join(ranges, ",")
+}
+
+// WriteLCOV writes the report in the LCOV tracefile format.
+func (c *Coverage) WriteLCOV(w io.Writer) error {
+	var out strings.Builder
+
+	for _, report := range c.Report() {
+		fmt.Fprintf(&out, "TN:\nSF:%s\n", report.Name)
+
+		for i, branch := range report.Branches {
+			fmt.Fprintf(&out, "BRDA:%d,%d,0,%s\n", branch.Line, i, lcovCount(branch.Then))
+			fmt.Fprintf(&out, "BRDA:%d,%d,1,%s\n", branch.Line, i, lcovCount(branch.Else))
+		}
+
+		fmt.Fprintf(&out, "BRF:%d\nBRH:%d\n", len(report.Branches)*2, report.BranchesCovered())
+
+		lines := lo.Keys(report.Lines)
+		slices.Sort(lines)
+
+		for _, line := range lines {
+			fmt.Fprintf(&out, "DA:%d,%d\n", line, report.Lines[line])
+		}
+
+		fmt.Fprintf(&out, "LF:%d\nLH:%d\nend_of_record\n", len(report.Lines), report.Covered())
+	}
+
+	_, err := io.WriteString(w, out.String())
+	if err != nil {
+		return fmt.Errorf("writing coverage error: %w", err)
+	}
+
+	return nil
+}
+
+// lcovCount returns the number of times a branch was taken, LCOV uses "-" for branches which never were.
+func lcovCount(count int) string {
+	if count == 0 {
+		return "-"
+	}
+
+	return fmt.Sprint(count)
+}
+
+//nolint:gochecknoglobals
+var htmlTemplate = template.Must(template.New("coverage").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Monkey coverage</title>
+<style>
+body { font-family: sans-serif; }
+pre { font-family: monospace; }
+.line { display: block; }
+.covered { background: #d7f5d7; }
+.uncovered { background: #f8d6d6; }
+.number { display: inline-block; width: 4em; color: #888; }
+.hits { display: inline-block; width: 4em; color: #888; text-align: right; margin-right: 1em; }
+</style>
+</head>
+<body>
+{{range .}}
+<h2>{{.Name}} ({{printf "%.1f" .Percent}}%)</h2>
+<pre>{{range .Lines}}<span class="line {{.Class}}"><span class="number">{{.Number}}</span><span class="hits">{{.Hits}}</span>{{.Text}}</span>{{end}}</pre>
+{{end}}
+</body>
+</html>
+`))
+
+type htmlLine struct {
+	Number int
+	Hits   string
+	Class  string
+	Text   string
+}
+
+type htmlFile struct {
+	Name    string
+	Percent float64
+	Lines   []htmlLine
+}
+
+// WriteHTML writes an HTML page with sources of covered files, executed lines are green, not executed are red.
+func (c *Coverage) WriteHTML(w io.Writer) error {
+	files := lo.Map(c.Report(), func(report FileReport, _ int) htmlFile {
+		lines := lo.Map(strings.Split(report.Source, "\n"), func(text string, i int) htmlLine {
+			line := htmlLine{Number: i + 1, Text: text}
+
+			if hits, ok := report.Lines[i+1]; ok {
+				line.Hits = fmt.Sprintf("%dx", hits)
+				line.Class = lo.Ternary(hits > 0, "covered", "uncovered")
+			}
+
+			return line
+		})
+
+		return htmlFile{Name: report.Name, Percent: report.Percent(), Lines: lines}
+	})
+
+	err := htmlTemplate.Execute(w, files)
+	if err != nil {
+		return fmt.Errorf("writing coverage error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package dap
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+type mode int
+
+const (
+	modeContinue mode = iota
+	modeEntry
+	modeStepIn
+	modeStepOver
+	modeStepOut
+	modeTerminate
+)
+
+// control decides where the program stops next.
+type control struct {
+	mode      mode
+	depth     int // call depth where the last step started
+	pause     bool
+	terminate bool
+
+	// skipFile, skipLine and skipDepth prevent stopping at the same breakpoint again when several statements
+	// start on its line, they are reset once the program leaves the line.
+	skipFile  string
+	skipLine  int
+	skipDepth int
+}
+
+// reason returns why the program stops at the event, empty if it doesn't, breakpoints are lines of the file
+// of the event.
+func (c *control) reason(event evaluator.Event, breakpoints map[int]bool) string { //nolint:cyclop
+	line := event.Node.Pos().Line
+
+	if event.File != c.skipFile || line != c.skipLine || event.Depth != c.skipDepth {
+		c.skipLine = 0
+	}
+
+	switch {
+	case c.pause:
+		return "pause"
+	case c.mode == modeEntry:
+		return "entry"
+	case c.mode == modeStepIn,
+		c.mode == modeStepOver && event.Depth <= c.depth,
+		c.mode == modeStepOut && event.Depth < c.depth:
+		return "step"
+	case breakpoints[line] && c.skipLine == 0:
+		return "breakpoint"
+	default:
+		return ""
+	}
+}
+
+// env is implemented by obj.Env, it allows listing bindings of a scope.
+type env interface {
+	obj.EnvGetter
+	Names() []string
+	Parent() obj.EnvGetter
+}
+
+// stopped holds the program's state while it's stopped, frames go top first.
+type stopped struct {
+	frames []evaluator.Frame
+	envs   []env // variables references are indexes of envs starting from 1
+	scopes [][]Scope
+}
+
+func newStopped(stack []evaluator.Frame) *stopped {
+	slices.Reverse(stack)
+
+	state := &stopped{frames: stack}
+
+	for i, frame := range stack {
+		scopes := []Scope{}
+
+		var current obj.EnvGetter = frame.Env
+
+		for current != nil {
+			scope, ok := current.(env)
+			if !ok {
+				break
+			}
+
+			state.envs = append(state.envs, scope)
+			scopes = append(scopes, Scope{Name: "Closure", VariablesReference: len(state.envs)})
+			current = scope.Parent()
+		}
+
+		// Function frames start with their own scope, the outermost one is global for every frame.
+		if len(scopes) > 1 && i < len(stack)-1 {
+			scopes[0].Name = "Local"
+		}
+
+		if len(scopes) > 0 {
+			scopes[len(scopes)-1].Name = "Global"
+		}
+
+		state.scopes = append(state.scopes, scopes)
+	}
+
+	return state
+}
+
+func (s *Server) hook(eval evaluator.Evaluator, event evaluator.Event) error {
+	s.mu.Lock()
+
+	if s.control.terminate {
+		s.mu.Unlock()
+
+		return errTerminated
+	}
+
+	reason := s.control.reason(event, s.breakpoints[s.hostPath(event.File)])
+	if reason == "" {
+		s.mu.Unlock()
+
+		return nil
+	}
+
+	s.stopped = newStopped(eval.Stack())
+	s.control.pause = false
+	s.control.skipFile = event.File
+	s.control.skipLine = event.Node.Pos().Line
+	s.control.skipDepth = event.Depth
+	s.mu.Unlock()
+
+	err := s.event("stopped", StoppedEventBody{Reason: reason, ThreadID: threadID, AllThreadsStopped: true})
+	if err != nil {
+		return err
+	}
+
+	next := <-s.resume
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopped = nil
+	s.control.mode = next
+	s.control.depth = event.Depth
+
+	if next == modeTerminate || s.control.terminate {
+		return errTerminated
+	}
+
+	return nil
+}
+
+// resumeWith lets the stopped program continue, the caller must hold the lock.
+func (s *Server) resumeWith(next mode) {
+	select {
+	case s.resume <- next:
+	default: // already resumed
+	}
+}
+
+func resumeWith(next mode) handler {
+	return func(s *Server, _ json.RawMessage) (any, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.stopped == nil {
+			return nil, ErrNotStopped
+		}
+
+		s.resumeWith(next)
+
+		if next == modeContinue {
+			return ContinueResponseBody{AllThreadsContinued: true}, nil
+		}
+
+		return nil, nil //nolint:nilnil
+	}
+}
+
+func (s *Server) stackTrace(_ json.RawMessage) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped == nil {
+		return nil, ErrNotStopped
+	}
+
+	body := StackTraceResponseBody{StackFrames: []StackFrame{}, TotalFrames: len(s.stopped.frames)}
+
+	for i, frame := range s.stopped.frames {
+		stackFrame := StackFrame{ID: i, Name: frame.Name}
+
+		if frame.Node != nil {
+			path := s.hostPath(frame.File)
+			stackFrame.Source = Source{Name: filepath.Base(path), Path: path}
+			stackFrame.Line = frame.Node.Pos().Line
+			stackFrame.Column = frame.Node.Pos().Column
+		}
+
+		body.StackFrames = append(body.StackFrames, stackFrame)
+	}
+
+	return body, nil
+}
+
+func (s *Server) scopes(args ScopesArguments) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped == nil {
+		return nil, ErrNotStopped
+	}
+
+	if args.FrameID < 0 || args.FrameID >= len(s.stopped.scopes) {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownFrame, args.FrameID)
+	}
+
+	return ScopesResponseBody{Scopes: s.stopped.scopes[args.FrameID]}, nil
+}
+
+func (s *Server) variables(args VariablesArguments) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped == nil {
+		return nil, ErrNotStopped
+	}
+
+	if args.VariablesReference < 1 || args.VariablesReference > len(s.stopped.envs) {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVariable, args.VariablesReference)
+	}
+
+	scope := s.stopped.envs[args.VariablesReference-1]
+	body := VariablesResponseBody{Variables: []Variable{}}
+
+	for _, name := range scope.Names() {
+		value, err := scope.Get(name)
+		if err != nil {
+			continue
+		}
+
+		body.Variables = append(body.Variables, Variable{Name: name, Value: value.Inspect(), Type: value.TypeName()})
+	}
+
+	return body, nil
+}
@@ -0,0 +1,420 @@
+// Package dap implements a Debug Adapter Protocol server which debugs a single Monkey program.
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/evaluator"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/module"
+	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/stdlib"
+	"github.com/zhulik/monkey/transport"
+)
+
+// threadID is the only thread, Monkey programs are single-threaded.
+const threadID = 1
+
+var (
+	ErrNotLaunched     = errors.New("program is not launched")
+	ErrNotStopped      = errors.New("program is not stopped")
+	ErrUnknownCommand  = errors.New("unknown command")
+	ErrUnknownFrame    = errors.New("unknown frame")
+	ErrUnknownVariable = errors.New("unknown variables reference")
+	ErrOutsideRoot     = errors.New("program is outside of the module root")
+
+	errTerminated = errors.New("terminated by the debugger")
+)
+
+type handler func(s *Server, args json.RawMessage) (any, error)
+
+var handlers = map[string]handler{ //nolint:gochecknoglobals
+	"initialize":        (*Server).initialize,
+	"launch":            withArguments((*Server).launch),
+	"setBreakpoints":    withArguments((*Server).setBreakpoints),
+	"configurationDone": (*Server).configurationDone,
+	"threads":           (*Server).threads,
+	"stackTrace":        (*Server).stackTrace,
+	"scopes":            withArguments((*Server).scopes),
+	"variables":         withArguments((*Server).variables),
+	"continue":          resumeWith(modeContinue),
+	"next":              resumeWith(modeStepOver),
+	"stepIn":            resumeWith(modeStepIn),
+	"stepOut":           resumeWith(modeStepOut),
+	"pause":             (*Server).pause,
+	"terminate":         (*Server).terminate,
+	"disconnect":        (*Server).terminate,
+}
+
+// Server serves a single debugging session. Requests are read in Serve while the program is evaluated
+// in its own goroutine, the evaluation blocks in the debug hook while the program is stopped.
+type Server struct {
+	in *bufio.Reader
+
+	writeMu sync.Mutex
+	out     io.Writer
+	seq     int
+
+	mu          sync.Mutex
+	root        string // modules are imported from the filesystem rooted there
+	path        string
+	program     *ast.Program
+	breakpoints map[string]map[int]bool // lines of breakpoints by absolute paths of files
+	configured  bool
+	running     bool
+	control     control
+	stopped     *stopped
+
+	resume chan mode
+	done   chan struct{}
+}
+
+func NewServer(in io.Reader, out io.Writer) *Server {
+	return &Server{
+		in:          bufio.NewReader(in),
+		out:         out,
+		breakpoints: map[string]map[int]bool{},
+		resume:      make(chan mode, 1),
+		done:        make(chan struct{}),
+	}
+}
+
+// Serve handles requests until the client disconnects or closes the input, a running program is terminated then.
+func (s *Server) Serve() error {
+	defer s.stop()
+
+	for {
+		body, err := transport.ReadMessage(s.in)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err //nolint:wrapcheck
+		}
+
+		var msg message
+
+		err = json.Unmarshal(body, &msg)
+		if err != nil {
+			return fmt.Errorf("decoding message error: %w", err)
+		}
+
+		if msg.Type != "request" {
+			continue
+		}
+
+		err = s.handle(msg)
+		if err != nil {
+			return err
+		}
+
+		if msg.Command == "disconnect" {
+			return nil
+		}
+	}
+}
+
+func (s *Server) handle(msg message) error {
+	handler, ok := handlers[msg.Command]
+	if !ok {
+		return s.respond(msg, nil, fmt.Errorf("%w: %s", ErrUnknownCommand, msg.Command))
+	}
+
+	body, err := handler(s, msg.Arguments)
+
+	rErr := s.respond(msg, body, err)
+	if rErr != nil || err != nil {
+		return rErr
+	}
+
+	// Configuration requests are accepted once the program is loaded.
+	if msg.Command == "launch" {
+		return s.event("initialized", nil)
+	}
+
+	return nil
+}
+
+func (s *Server) respond(msg message, body any, err error) error {
+	resp := response{Type: "response", RequestSeq: msg.Seq, Success: err == nil, Command: msg.Command, Body: body}
+	if err != nil {
+		resp.Message = err.Error()
+	}
+
+	return s.send(&resp, &resp.Seq)
+}
+
+func (s *Server) event(name string, body any) error {
+	evt := event{Type: "event", Event: name, Body: body}
+
+	return s.send(&evt, &evt.Seq)
+}
+
+func (s *Server) send(msg any, seq *int) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.seq++
+	*seq = s.seq
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("encoding message error: %w", err)
+	}
+
+	return transport.WriteMessage(s.out, body) //nolint:wrapcheck
+}
+
+func (s *Server) initialize(_ json.RawMessage) (any, error) {
+	return Capabilities{SupportsConfigurationDoneRequest: true, SupportsTerminateRequest: true}, nil
+}
+
+func (s *Server) launch(args LaunchArguments) (any, error) {
+	path, err := filepath.Abs(args.Program)
+	if err != nil {
+		return nil, fmt.Errorf("resolving program path error: %w", err)
+	}
+
+	root := args.ModuleRoot
+	if root == "" {
+		root = filepath.Dir(path)
+	}
+
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("resolving module root error: %w", err)
+	}
+
+	if name, rErr := filepath.Rel(root, path); rErr != nil || !filepath.IsLocal(name) {
+		return nil, fmt.Errorf("%w: %s", ErrOutsideRoot, args.Program)
+	}
+
+	program, err := parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.root = root
+	s.path = path
+	s.program = program
+
+	if args.StopOnEntry {
+		s.control.mode = modeEntry
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
+func (s *Server) setBreakpoints(args SetBreakpointsArguments) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.program == nil {
+		return nil, ErrNotLaunched
+	}
+
+	body := SetBreakpointsResponseBody{Breakpoints: []Breakpoint{}}
+
+	path, err := filepath.Abs(args.Source.Path)
+	if err != nil {
+		return nil, fmt.Errorf("resolving source path error: %w", err)
+	}
+
+	// Breakpoints can be set in modules the program imports, they are parsed to verify the lines.
+	program := s.program
+
+	var pErr error
+	if path != s.path {
+		program, pErr = parse(path)
+	}
+
+	lines := map[int]bool{}
+	if pErr == nil {
+		lines = statementLines(program)
+	}
+
+	breakpoints := map[int]bool{}
+	s.breakpoints[path] = breakpoints
+
+	for _, bp := range args.Breakpoints {
+		breakpoint := Breakpoint{Line: bp.Line}
+
+		switch {
+		case pErr != nil:
+			breakpoint.Message = pErr.Error()
+		case !lines[bp.Line]:
+			breakpoint.Message = "no statement starts on this line"
+		default:
+			breakpoint.Verified = true
+			breakpoints[bp.Line] = true
+		}
+
+		body.Breakpoints = append(body.Breakpoints, breakpoint)
+	}
+
+	return body, nil
+}
+
+func (s *Server) configurationDone(_ json.RawMessage) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.program == nil {
+		return nil, ErrNotLaunched
+	}
+
+	if !s.configured {
+		s.configured = true
+		s.running = true
+
+		go s.run(s.program)
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
+func (s *Server) threads(_ json.RawMessage) (any, error) {
+	return ThreadsResponseBody{Threads: []Thread{{ID: threadID, Name: "main"}}}, nil
+}
+
+func (s *Server) pause(_ json.RawMessage) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.control.pause = true
+
+	return nil, nil //nolint:nilnil
+}
+
+func (s *Server) terminate(_ json.RawMessage) (any, error) {
+	s.stop()
+
+	return nil, nil //nolint:nilnil
+}
+
+// stop terminates the running program and waits for it to finish.
+func (s *Server) stop() {
+	s.mu.Lock()
+	running := s.running
+	s.control.terminate = true
+
+	if s.stopped != nil {
+		s.resumeWith(modeTerminate)
+	}
+	s.mu.Unlock()
+
+	if running {
+		<-s.done
+	}
+}
+
+func (s *Server) run(program *ast.Program) {
+	defer close(s.done)
+
+	var eval evaluator.Evaluator
+
+	opts := append(stdlib.Options(),
+		evaluator.WithFile(s.name(s.path)),
+		module.New(os.DirFS(s.root)).Option(),
+		evaluator.WithHook(func(event evaluator.Event) error {
+			return s.hook(eval, event)
+		}),
+	)
+
+	eval = evaluator.New(opts...)
+
+	result, err := eval.Eval(program)
+
+	switch {
+	case errors.Is(err, errTerminated):
+		s.exit(0)
+	case err != nil:
+		s.output("stderr", "Evaluation error: "+err.Error()+"\n")
+		s.exit(1)
+	default:
+		s.output("console", result.Inspect()+"\n")
+		s.exit(0)
+	}
+}
+
+func (s *Server) output(category, text string) {
+	_ = s.event("output", OutputEventBody{Category: category, Output: text})
+}
+
+func (s *Server) exit(code int) {
+	s.mu.Lock()
+	s.running = false
+	s.mu.Unlock()
+
+	_ = s.event("exited", ExitedEventBody{ExitCode: code})
+	_ = s.event("terminated", nil)
+}
+
+func withArguments[T any](fn func(s *Server, args T) (any, error)) handler {
+	return func(s *Server, raw json.RawMessage) (any, error) {
+		var args T
+
+		err := json.Unmarshal(raw, &args)
+		if err != nil {
+			return nil, fmt.Errorf("decoding arguments error: %w", err)
+		}
+
+		return fn(s, args)
+	}
+}
+
+// parse reads and parses the file.
+func parse(path string) (*ast.Program, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading program error: %w", err)
+	}
+
+	program, err := parser.New(lexer.New(string(src))).ParseProgram()
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %w", err)
+	}
+
+	return program, nil
+}
+
+// name returns the name of the file in the filesystem modules are imported from.
+func (s *Server) name(path string) string {
+	name, _ := filepath.Rel(s.root, path)
+
+	return filepath.ToSlash(name)
+}
+
+// hostPath returns the absolute path of the file named in the filesystem modules are imported from.
+func (s *Server) hostPath(name string) string {
+	return filepath.Join(s.root, filepath.FromSlash(name))
+}
+
+// statementLines returns lines where statements start, breakpoints can be set only there.
+func statementLines(program *ast.Program) map[int]bool {
+	lines := map[int]bool{}
+
+	ast.Inspect(program, func(node ast.Node) bool {
+		if stmt, ok := node.(ast.Statement); ok {
+			if _, isBlock := stmt.(*ast.BlockStatement); !isBlock {
+				lines[stmt.Pos().Line] = true
+			}
+		}
+
+		return true
+	})
+
+	return lines
+}
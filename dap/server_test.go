@@ -0,0 +1,329 @@
+package dap_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/dap"
+	"github.com/zhulik/monkey/transport"
+)
+
+const program = `let fib = fn(n) {
+  if (n < 2) {
+    return n;
+  }
+  fib(n - 1) + fib(n - 2)
+};
+let x = 1;
+let result = fib(3);
+result + x
+`
+
+// client is a scripted DAP client talking to an in-process server, messages are read in the background
+// so the server never blocks on writing.
+type client struct {
+	in       io.WriteCloser
+	messages chan map[string]any
+	seq      int
+	events   []map[string]any
+	done     chan error
+}
+
+func newClient() *client {
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+
+	c := &client{in: clientOut, messages: make(chan map[string]any, 100), done: make(chan error, 1)}
+
+	go func() {
+		c.done <- dap.NewServer(serverIn, serverOut).Serve()
+		serverOut.Close()
+	}()
+
+	go func() {
+		defer GinkgoRecover()
+		defer close(c.messages)
+
+		reader := bufio.NewReader(clientIn)
+
+		for {
+			body, err := transport.ReadMessage(reader)
+			if err != nil {
+				return
+			}
+
+			var msg map[string]any
+			Expect(json.Unmarshal(body, &msg)).To(Succeed())
+
+			c.messages <- msg
+		}
+	}()
+
+	return c
+}
+
+func (c *client) read() map[string]any {
+	var msg map[string]any
+
+	Eventually(c.messages).Should(Receive(&msg))
+
+	return msg
+}
+
+// request sends a request and returns its response, events received meanwhile are queued.
+func (c *client) request(command string, args any) map[string]any {
+	c.seq++
+
+	body, err := json.Marshal(map[string]any{"seq": c.seq, "type": "request", "command": command, "arguments": args})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(transport.WriteMessage(c.in, body)).To(Succeed())
+
+	for {
+		msg := c.read()
+		if msg["type"] == "response" {
+			Expect(msg["request_seq"]).To(BeEquivalentTo(c.seq))
+
+			return msg
+		}
+
+		c.events = append(c.events, msg)
+	}
+}
+
+func (c *client) body(command string, args any) map[string]any {
+	response := c.request(command, args)
+	Expect(response).To(HaveKeyWithValue("success", true), fmt.Sprint(response["message"]))
+
+	body, _ := response["body"].(map[string]any)
+
+	return body
+}
+
+// event waits for the event with the name, other events are dropped.
+func (c *client) event(name string) map[string]any {
+	for {
+		if len(c.events) == 0 {
+			c.events = append(c.events, c.read())
+		}
+
+		msg := c.events[0]
+		c.events = c.events[1:]
+
+		if msg["event"] == name {
+			body, _ := msg["body"].(map[string]any)
+
+			return body
+		}
+	}
+}
+
+func (c *client) launch(path string, stopOnEntry bool, lines ...int) {
+	Expect(c.body("initialize", map[string]any{"adapterID": "monkey"})).To(HaveKeyWithValue("supportsConfigurationDoneRequest", true))
+	c.body("launch", map[string]any{"program": path, "stopOnEntry": stopOnEntry})
+	c.event("initialized")
+
+	c.setBreakpoints(path, lines...)
+	c.body("configurationDone", nil)
+}
+
+func (c *client) setBreakpoints(path string, lines ...int) []any {
+	return c.body("setBreakpoints", map[string]any{
+		"source":      map[string]any{"path": path},
+		"breakpoints": lo.Map(lines, func(line, _ int) map[string]any { return map[string]any{"line": line} }),
+	})["breakpoints"].([]any)
+}
+
+// position returns the stop reason and the line of every frame, top first.
+func (c *client) position() (string, []string) {
+	reason := c.event("stopped")["reason"].(string)
+	frames := c.body("stackTrace", map[string]any{"threadId": 1})["stackFrames"].([]any)
+
+	return reason, lo.Map(frames, func(frame any, _ int) string {
+		f := frame.(map[string]any)
+
+		return fmt.Sprintf("%s:%v", f["name"], f["line"])
+	})
+}
+
+func (c *client) variables(frame int) map[string]map[string]string {
+	result := map[string]map[string]string{}
+
+	for _, scope := range c.body("scopes", map[string]any{"frameId": frame})["scopes"].([]any) {
+		scope := scope.(map[string]any)
+		variables := c.body("variables", map[string]any{"variablesReference": scope["variablesReference"]})["variables"].([]any)
+
+		result[scope["name"].(string)] = lo.SliceToMap(variables, func(variable any) (string, string) {
+			v := variable.(map[string]any)
+
+			return v["name"].(string), fmt.Sprintf("%s %s", v["type"], v["value"])
+		})
+	}
+
+	return result
+}
+
+func write(source string) string {
+	path := filepath.Join(GinkgoT().TempDir(), "main.mk")
+	Expect(os.WriteFile(path, []byte(source), 0o600)).To(Succeed())
+
+	return path
+}
+
+var _ = Describe("Server", func() {
+	var c *client
+
+	BeforeEach(func() {
+		c = newClient()
+	})
+
+	AfterEach(func() {
+		c.body("disconnect", nil)
+		Eventually(c.done).Should(Receive(BeNil()))
+	})
+
+	It("stops at breakpoints and shows variables of each scope", func() {
+		path := write(program)
+		c.launch(path, false, 3)
+
+		reason, frames := c.position()
+		Expect(reason).To(Equal("breakpoint"))
+		Expect(frames).To(Equal([]string{"fib:3", "fib:5", "fib:5", "main:8"}))
+
+		variables := c.variables(0)
+		Expect(variables).To(HaveKeyWithValue("Local", map[string]string{"n": "Integer 1"}))
+		Expect(variables).To(HaveKeyWithValue("Global", HaveKeyWithValue("x", "Integer 1")))
+		Expect(variables["Global"]).ToNot(HaveKey("result"))
+
+		Expect(c.variables(2)).To(HaveKeyWithValue("Local", map[string]string{"n": "Integer 3"}))
+		Expect(c.variables(3)).To(HaveKeyWithValue("Global", HaveKey("fib")))
+
+		c.body("continue", map[string]any{"threadId": 1})
+
+		reason, frames = c.position()
+		Expect(reason).To(Equal("breakpoint"))
+		Expect(frames).To(Equal([]string{"fib:3", "fib:5", "fib:5", "main:8"}))
+		Expect(c.variables(0)).To(HaveKeyWithValue("Local", map[string]string{"n": "Integer 0"}))
+
+		c.setBreakpoints(path)
+		c.body("continue", map[string]any{"threadId": 1})
+
+		Expect(c.event("output")).To(HaveKeyWithValue("output", "3\n"))
+		Expect(c.event("exited")).To(HaveKeyWithValue("exitCode", BeEquivalentTo(0)))
+		c.event("terminated")
+	})
+
+	It("stops at breakpoints in imported modules", func() {
+		path := write(`import { double } from "./lib/math";` + "\ndouble(2)")
+		lib := filepath.Join(filepath.Dir(path), "lib", "math.mk")
+		Expect(os.MkdirAll(filepath.Dir(lib), 0o755)).To(Succeed())
+		Expect(os.WriteFile(lib, []byte("export let double = fn(n) {\n  n * 2\n};\n"), 0o600)).To(Succeed())
+
+		c.body("initialize", nil)
+		c.body("launch", map[string]any{"program": path})
+		c.event("initialized")
+
+		Expect(c.setBreakpoints(lib, 2)[0]).To(HaveKeyWithValue("verified", true))
+		c.body("configurationDone", nil)
+
+		reason, frames := c.position()
+		Expect(reason).To(Equal("breakpoint"))
+		Expect(frames).To(Equal([]string{"double:2", "main:2"}))
+
+		frame := c.body("stackTrace", map[string]any{"threadId": 1})["stackFrames"].([]any)[0].(map[string]any)
+		Expect(frame).To(HaveKeyWithValue("source", HaveKeyWithValue("path", lib)))
+
+		c.body("continue", map[string]any{"threadId": 1})
+		Expect(c.event("output")).To(HaveKeyWithValue("output", "4\n"))
+	})
+
+	It("imports modules only from the module root", func() {
+		path := write(`import "../lib"; 1`)
+
+		c.body("initialize", nil)
+		c.body("launch", map[string]any{"program": path})
+		c.event("initialized")
+		c.body("configurationDone", nil)
+
+		Expect(c.event("output")).To(HaveKeyWithValue("output", ContainSubstring("module is outside of the filesystem")))
+
+		Expect(c.request("launch", map[string]any{"program": path, "moduleRoot": filepath.Join(path, "lib")})).
+			To(HaveKeyWithValue("message", ContainSubstring("program is outside of the module root")))
+	})
+
+	It("steps in, over and out", func() {
+		c.launch(write(program), true)
+
+		steps := []struct {
+			command string
+			reason  string
+			frames  []string
+		}{
+			{"", "entry", []string{"main:1"}},
+			{"next", "step", []string{"main:7"}},
+			{"next", "step", []string{"main:8"}},
+			{"stepIn", "step", []string{"fib:2", "main:8"}},
+			{"next", "step", []string{"fib:5", "main:8"}},
+			{"stepIn", "step", []string{"fib:2", "fib:5", "main:8"}},
+			{"stepOut", "step", []string{"main:9"}},
+		}
+
+		for _, step := range steps {
+			if step.command != "" {
+				c.body(step.command, map[string]any{"threadId": 1})
+			}
+
+			reason, frames := c.position()
+			Expect(reason).To(Equal(step.reason))
+			Expect(frames).To(Equal(step.frames), step.command)
+		}
+
+		c.body("continue", map[string]any{"threadId": 1})
+		Expect(c.event("exited")).To(HaveKeyWithValue("exitCode", BeEquivalentTo(0)))
+	})
+
+	It("verifies breakpoints", func() {
+		path := write(program)
+
+		c.body("initialize", nil)
+		c.body("launch", map[string]any{"program": path})
+
+		breakpoints := c.setBreakpoints(path, 3, 4)
+		Expect(breakpoints[0]).To(HaveKeyWithValue("verified", true))
+		Expect(breakpoints[1]).To(HaveKeyWithValue("verified", false))
+
+		breakpoints = c.setBreakpoints("other.mk", 3)
+		Expect(breakpoints[0]).To(HaveKeyWithValue("verified", false))
+	})
+
+	It("terminates a stopped program", func() {
+		c.launch(write(program), true)
+		c.event("stopped")
+
+		c.body("terminate", nil)
+		Expect(c.event("exited")).To(HaveKeyWithValue("exitCode", BeEquivalentTo(0)))
+	})
+
+	It("reports evaluation errors", func() {
+		c.launch(write("let a = 1;\na / 0"), false)
+
+		Expect(c.event("output")).To(And(
+			HaveKeyWithValue("category", "stderr"),
+			HaveKeyWithValue("output", ContainSubstring("division by zero")),
+		))
+		Expect(c.event("exited")).To(HaveKeyWithValue("exitCode", BeEquivalentTo(1)))
+	})
+
+	It("fails requests which need a stopped program", func() {
+		c.body("initialize", nil)
+
+		Expect(c.request("stackTrace", map[string]any{"threadId": 1})).To(HaveKeyWithValue("success", false))
+		Expect(c.request("foo", nil)).To(HaveKeyWithValue("message", ContainSubstring("unknown command")))
+	})
+})
@@ -0,0 +1,27 @@
+package evaluator
+
+import (
+	"slices"
+
+	"github.com/samber/lo"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+// BuiltinsFunc creates global names provided by the host, it's called once when the evaluator is created.
+type BuiltinsFunc func(evaluator Evaluator) map[string]obj.Object
+
+// WithBuiltins provides global names visible in the main program and in all modules without imports.
+// Bindings of programs shadow them.
+func WithBuiltins(builtins BuiltinsFunc) Option {
+	return func(s *state) {
+		s.builtinFuncs = append(s.builtinFuncs, builtins)
+	}
+}
+
+// Builtins returns sorted names of builtins.
+func (e Evaluator) Builtins() []string {
+	names := lo.Keys(e.builtins)
+	slices.Sort(names)
+
+	return names
+}
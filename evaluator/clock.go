@@ -0,0 +1,55 @@
+package evaluator
+
+import (
+	"context"
+	"time"
+)
+
+// Clock provides the current time and sleeping to the standard library, a fake clock makes scripts deterministic.
+type Clock interface {
+	Now() time.Time
+	// Sleep pauses for the duration, it returns the error of the context if it's done earlier.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	case <-timer.C:
+		return nil
+	}
+}
+
+// WithClock sets the clock used by the standard library, by default it's the system clock.
+func WithClock(clock Clock) Option {
+	return func(s *state) {
+		s.clock = clock
+	}
+}
+
+// WithContext sets the context of the evaluation, function calls fail with the error of the context once it's done.
+func WithContext(ctx context.Context) Option {
+	return func(s *state) {
+		s.ctx = ctx
+	}
+}
+
+// Clock returns the clock of the evaluator.
+func (e Evaluator) Clock() Clock {
+	return e.clock
+}
+
+// Context returns the context of the evaluation.
+func (e Evaluator) Context() context.Context {
+	return e.ctx
+}
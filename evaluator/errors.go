@@ -0,0 +1,154 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/ast"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+const defaultErrorKind = "Error"
+
+type errorKind struct {
+	err  error
+	kind string
+}
+
+var defaultErrorKinds = []errorKind{ //nolint:gochecknoglobals
+	{obj.ErrDevisionByZero, "DivisionByZero"},
+	{obj.ErrWronArgumentType, "WrongArgumentType"},
+	{obj.ErrWrongNumberOfArguments, "WrongNumberOfArguments"},
+	{obj.ErrIndexOutOfRange, "IndexOutOfRange"},
+	{obj.ErrUnknownIdentifier, "UnknownIdentifier"},
+	{obj.ErrNotCallable, "NotCallable"},
+	{obj.ErrUndefinedMethod, "UndefinedMethod"},
+	{obj.ErrNotExported, "NotExported"},
+	{obj.ErrNoMembers, "NoMembers"},
+	{obj.ErrUnknownMember, "UnknownMember"},
+	{obj.ErrUnhashable, "Unhashable"},
+	{ErrNonBoolCondition, "NonBoolCondition"},
+	{ErrUnknownInfixOperator, "UnknownOperator"},
+	{ErrUnknownPrefixOperator, "UnknownOperator"},
+	{ErrImportsDisabled, "ImportsDisabled"},
+}
+
+// hookError is returned when a hook aborts the evaluation, scripts can't catch it.
+type hookError struct {
+	error
+}
+
+func (e hookError) Unwrap() error {
+	return e.error
+}
+
+// WithErrorKind names the kind of errors matching err with errors.Is, scripts see the kind when they catch
+// such errors. Kinds added with the option take precedence over the built-in ones, errors of unknown kinds
+// are of kind Error.
+func WithErrorKind(err error, kind string) Option {
+	return func(s *state) {
+		s.errorKinds = append(s.errorKinds, errorKind{err: err, kind: kind})
+	}
+}
+
+// ErrorKind returns the kind of the host error.
+func (e Evaluator) ErrorKind(err error) string {
+	for _, kinds := range [][]errorKind{e.errorKinds, defaultErrorKinds} {
+		for _, kind := range kinds {
+			if errors.Is(err, kind.err) {
+				return kind.kind
+			}
+		}
+	}
+
+	return defaultErrorKind
+}
+
+// catchable converts the error to an Error value with the current stack unless it is already one. Errors
+// of hooks and of the done context are returned as is, they abort the evaluation.
+func (e Evaluator) catchable(err error) error {
+	var hookErr hookError
+	if errors.As(err, &hookErr) || e.ctx.Err() != nil && errors.Is(err, e.ctx.Err()) {
+		return err
+	}
+
+	if errors.As(err, &obj.Error{}) {
+		return err
+	}
+
+	return obj.Error{Kind: e.ErrorKind(err), Message: err.Error(), Stack: e.stackTrace(), Cause: err}
+}
+
+// stackTrace returns frames of the call stack as "name line:column".
+func (e Evaluator) stackTrace() []string {
+	return lo.Map(e.frames, func(frame Frame, _ int) string {
+		if frame.Node == nil {
+			return frame.Name
+		}
+
+		return fmt.Sprintf("%s %s", frame.Name, frame.Node.Pos())
+	})
+}
+
+// evalThrowStatement throws an Error or a String, which becomes the message of an Error of kind Error.
+// Errors without a stack get the stack of the throw statement, rethrown errors keep theirs.
+func (e Evaluator) evalThrowStatement(node *ast.ThrowStatement, env obj.EnvGetSetter) (obj.Object, error) {
+	value, err := e.Eval(node.V, env)
+	if err != nil {
+		return nil, err
+	}
+
+	var thrown obj.Error
+
+	switch value := value.(type) {
+	case obj.Error:
+		thrown = value
+	case obj.String:
+		thrown = obj.Error{Kind: defaultErrorKind, Message: value.Value()}
+	default:
+		return obj.NIL, fmt.Errorf("%w: throw expects Error or String, given %s", obj.ErrWronArgumentType, value.TypeName())
+	}
+
+	if thrown.Stack == nil {
+		thrown.Stack = e.stackTrace()
+	}
+
+	return nil, thrown
+}
+
+// evalTryExpression evaluates the try block, if it throws, the catch block is evaluated with the Error bound
+// to the parameter. The finally block is evaluated unless the evaluation is aborted, its value is discarded
+// unless it returns, an error thrown by it replaces the result.
+func (e Evaluator) evalTryExpression(node *ast.TryExpression, env obj.EnvGetSetter) (obj.Object, error) {
+	result, err := e.Eval(node.V, env)
+
+	var thrown obj.Error
+
+	switch {
+	case err == nil:
+	case !errors.As(err, &thrown):
+		return nil, err
+	case node.Catch != nil:
+		catchEnv := obj.NewEnv(env)
+		catchEnv.Set(node.Param.V, thrown)
+
+		result, err = e.Eval(node.Catch, catchEnv)
+		if err != nil && !errors.As(err, &thrown) {
+			return nil, err
+		}
+	}
+
+	if node.Finally != nil {
+		final, fErr := e.Eval(node.Finally, env)
+		if fErr != nil {
+			return nil, fErr
+		}
+
+		if ret, ok := final.(ReturnValue); ok {
+			return ret, nil
+		}
+	}
+
+	return result, err
+}
@@ -1,8 +1,11 @@
 package evaluator
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"math/rand/v2"
 
 	"github.com/zhulik/monkey/ast"
 	obj "github.com/zhulik/monkey/evaluator/object"
@@ -14,16 +17,75 @@ var (
 	ErrUnknownPrefixOperator = errors.New("unknown prefix operator")
 
 	ErrNonBoolCondition = errors.New("condition must be a Boolean")
+	ErrImportsDisabled  = errors.New("imports are not enabled")
 )
 
 type ReturnValue struct {
 	obj.Object
 }
 
-type Evaluator struct{}
+type Option func(*state)
 
-func New() Evaluator {
-	return Evaluator{}
+// Evaluator is cheap to copy, copies share the state: options and the call stack.
+type Evaluator struct {
+	*state
+
+	file string // file of the module being evaluated, functions keep the evaluator of the module defining them
+}
+
+type state struct {
+	hooks        []Hook
+	branchHooks  []BranchHook
+	tracers      []Tracer
+	frames       []Frame
+	importer     Importer
+	modules      map[string]ModuleFunc
+	builtinFuncs []BuiltinsFunc
+	builtins     map[string]obj.Object
+	loaded       map[string]obj.Module
+	rand         *rand.Rand
+	regexps      *regexpCache
+	errorKinds   []errorKind
+	fileRoots    []string
+	clock        Clock
+	ctx          context.Context //nolint:containedctx
+	file         string
+}
+
+func New(opts ...Option) Evaluator {
+	state := &state{
+		frames:  []Frame{{Name: mainFrame}},
+		modules: map[string]ModuleFunc{},
+		loaded:  map[string]obj.Module{},
+		regexps: newRegexpCache(),
+		clock:   systemClock{},
+		ctx:     context.Background(),
+	}
+
+	for _, opt := range opts {
+		opt(state)
+	}
+
+	e := Evaluator{state: state, file: state.file}
+
+	state.builtins = map[string]obj.Object{}
+	for _, fn := range state.builtinFuncs {
+		maps.Copy(state.builtins, fn(e))
+	}
+
+	return e
+}
+
+// WithFile sets the file of the main program, imports are resolved relative to it.
+func WithFile(file string) Option {
+	return func(s *state) {
+		s.file = file
+	}
+}
+
+// File returns the file of the module being evaluated, empty if the program is not read from a file.
+func (e Evaluator) File() string {
+	return e.file
 }
 
 func (e Evaluator) Eval(node ast.Node, envs ...obj.EnvGetSetter) (obj.Object, error) { //nolint:cyclop,funlen
@@ -54,6 +116,9 @@ func (e Evaluator) Eval(node ast.Node, envs ...obj.EnvGetSetter) (obj.Object, er
 	case *ast.IntegerExpression:
 		return obj.New[obj.Integer](node.V), nil
 
+	case *ast.FloatExpression:
+		return obj.New[obj.Float](node.V), nil
+
 	case *ast.PrefixExpression:
 		return e.evalPrefixExpression(node, env)
 
@@ -73,6 +138,12 @@ func (e Evaluator) Eval(node ast.Node, envs ...obj.EnvGetSetter) (obj.Object, er
 	case *ast.ReturnStatement:
 		return e.evalReturnStatement(node, env)
 
+	case *ast.ThrowStatement:
+		return e.evalThrowStatement(node, env)
+
+	case *ast.TryExpression:
+		return e.evalTryExpression(node, env)
+
 	case *ast.LetStatement:
 		return e.evalLetStatement(node, env)
 
@@ -88,18 +159,40 @@ func (e Evaluator) Eval(node ast.Node, envs ...obj.EnvGetSetter) (obj.Object, er
 	case *ast.StringExpression:
 		return obj.New[obj.String](node.V), nil
 
+	case *ast.MemberExpression:
+		return e.evalMemberExpression(node, env)
+
+	case *ast.ArrayExpression:
+		return e.evalArrayExpression(node, env)
+
+	case *ast.IndexExpression:
+		return e.evalIndexExpression(node, env)
+
+	case *ast.HashExpression:
+		return e.evalHashExpression(node, env)
+
+	case *ast.ImportStatement:
+		return e.evalImportStatement(node, env)
+
+	case *ast.ExportStatement:
+		return e.Eval(node.V, env)
+
 	default:
 		return nil, fmt.Errorf("%w: unknown node type: %s", ErrParsingError, node.TokenLiteral())
 	}
 }
 
 func (e Evaluator) evalProgram(node *ast.Program, env obj.EnvGetSetter) (obj.Object, error) {
-	var result obj.Object
+	if len(e.frames) == 1 {
+		defer e.notify(e.frames[0])()
+	}
+
+	var result obj.Object = obj.NIL
 
 	var err error
 
 	for _, statement := range node.Statements {
-		result, err = e.Eval(statement, env)
+		result, err = e.evalStatement(statement, env)
 		if err != nil {
 			return nil, err
 		}
@@ -231,6 +324,9 @@ func (e Evaluator) evalInfixExpression(node *ast.InfixExpression, env obj.EnvGet
 
 		return op.OperatorNEQ(right) //nolint:wrapcheck
 
+	case "=~":
+		return e.evalMatch(left, right)
+
 	default:
 		return obj.NIL, fmt.Errorf("%w: %s", ErrUnknownInfixOperator, node.Operator)
 	}
@@ -247,6 +343,10 @@ func (e Evaluator) evalIfExpression(node *ast.IfExpression, env obj.EnvGetSetter
 		return obj.NIL, fmt.Errorf("%w, given: %s", ErrNonBoolCondition, condition.TypeName())
 	}
 
+	for _, hook := range e.branchHooks {
+		hook(node, cond.Value())
+	}
+
 	if cond.Value() {
 		return e.Eval(node.Then, env)
 	}
@@ -269,13 +369,96 @@ func (e Evaluator) evalLetStatement(node *ast.LetStatement, env obj.EnvGetSetter
 		return nil, err
 	}
 
+	// Functions are named after the first binding, the name is used in call stacks.
+	if fn, ok := value.(obj.Function); ok && fn.Name == "" {
+		fn.Name = node.Name.V
+		value = fn
+	}
+
 	env.Set(node.Name.V, value)
 
 	return value, nil
 }
 
 func (e Evaluator) evalIdentifierExpression(node *ast.IdentifierExpression, env obj.EnvGetSetter) (obj.Object, error) {
-	return env.Get(node.V) //nolint:wrapcheck
+	value, err := env.Get(node.V)
+	if err != nil {
+		if builtin, ok := e.builtins[node.V]; ok {
+			return builtin, nil
+		}
+
+		return nil, err //nolint:wrapcheck
+	}
+
+	return value, nil
+}
+
+func (e Evaluator) evalMemberExpression(node *ast.MemberExpression, env obj.EnvGetSetter) (obj.Object, error) {
+	value, err := e.Eval(node.V, env)
+	if err != nil {
+		return nil, err
+	}
+
+	members, ok := value.(obj.Members)
+	if !ok {
+		return obj.NIL, fmt.Errorf("%w: %s", obj.ErrNoMembers, value.TypeName())
+	}
+
+	return members.Member(node.Name.V) //nolint:wrapcheck
+}
+
+func (e Evaluator) evalArrayExpression(node *ast.ArrayExpression, env obj.EnvGetSetter) (obj.Object, error) {
+	elements := make([]obj.Object, len(node.V))
+
+	for i, element := range node.V {
+		value, err := e.Eval(element, env)
+		if err != nil {
+			return nil, err
+		}
+
+		elements[i] = value
+	}
+
+	return obj.New[obj.Array](elements), nil
+}
+
+func (e Evaluator) evalHashExpression(node *ast.HashExpression, env obj.EnvGetSetter) (obj.Object, error) {
+	pairs := make([]obj.HashPair, len(node.V))
+
+	for i, pair := range node.V {
+		key, err := e.Eval(pair.Key, env)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := e.Eval(pair.Value, env)
+		if err != nil {
+			return nil, err
+		}
+
+		pairs[i] = obj.HashPair{Key: key, Value: value}
+	}
+
+	return obj.NewHash(pairs...) //nolint:wrapcheck
+}
+
+func (e Evaluator) evalIndexExpression(node *ast.IndexExpression, env obj.EnvGetSetter) (obj.Object, error) {
+	value, err := e.Eval(node.V, env)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := e.Eval(node.Index, env)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := obj.CastOperator[obj.OperatorIndex](value)
+	if err != nil {
+		return obj.NIL, err //nolint:wrapcheck
+	}
+
+	return op.OperatorIndex(index) //nolint:wrapcheck
 }
 
 func (e Evaluator) evalCallExpression(node *ast.CallExpression, env obj.EnvGetSetter) (obj.Object, error) {
@@ -295,11 +478,38 @@ func (e Evaluator) evalCallExpression(node *ast.CallExpression, env obj.EnvGetSe
 		args = append(args, val)
 	}
 
-	res, err := function.(obj.Function).Call(args...)
+	callable, ok := function.(obj.Callable)
+	if !ok {
+		return obj.NIL, fmt.Errorf("%w: %s", obj.ErrNotCallable, function.TypeName())
+	}
+
+	return callable.Call(args...) //nolint:wrapcheck
+}
+
+// Call evaluates the function's body with the arguments, a frame is pushed to the call stack meanwhile.
+func (e Evaluator) Call(function obj.Function, args ...obj.Object) (obj.Object, error) {
+	name := function.Name
+	if name == "" {
+		name = anonymousFrame
+	}
+
+	if len(args) != len(function.Function.Arguments) {
+		return obj.NIL, fmt.Errorf("%w: %s expects %d, given %d",
+			obj.ErrWrongNumberOfArguments, name, len(function.Function.Arguments), len(args))
+	}
+
+	err := e.ctx.Err()
 	if err != nil {
 		return obj.NIL, err //nolint:wrapcheck
 	}
 
+	defer e.call(Frame{Name: name, Function: function.Function})()
+
+	res, err := e.Eval(function.Function.V, function.Bind(args))
+	if err != nil {
+		return obj.NIL, err
+	}
+
 	if ret, ok := res.(ReturnValue); ok {
 		return ret.Object, nil
 	}
@@ -313,7 +523,7 @@ func (e Evaluator) evalBlockStatement(node *ast.BlockStatement, env obj.EnvGetSe
 	var err error
 
 	for _, statement := range node.V {
-		result, err = e.Eval(statement, env)
+		result, err = e.evalStatement(statement, env)
 		if err != nil {
 			return nil, err
 		}
@@ -1,6 +1,10 @@
 package evaluator_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/samber/lo"
@@ -28,6 +32,9 @@ var _ = Describe("Evaluator", func() {
 				"true":  "true",
 				"false": "false",
 				"nil":   "nil",
+				"":      "nil",
+
+				"// only a comment": "nil",
 
 				"!!true":  "true",
 				"!true":   "false",
@@ -36,6 +43,12 @@ var _ = Describe("Evaluator", func() {
 
 				"-1": "-1",
 
+				"1_000_000": "1000000",
+				"0xFF":      "255",
+				"0x_FF":     "255",
+				"0o755":     "493",
+				"0b1010":    "10",
+
 				"1 + 1": "2",
 				"1 - 1": "0",
 				"2 * 2": "4",
@@ -44,6 +57,9 @@ var _ = Describe("Evaluator", func() {
 				"1 == 1": "true",
 				"1 != 1": "false",
 
+				`"abc" =~ "^a.c$"`:                         "true",
+				`let re = "b+"; ["abc" =~ re, "ac" =~ re]`: "[true, false]",
+
 				"1 > 1": "false",
 				"1 < 1": "false",
 
@@ -75,6 +91,38 @@ var _ = Describe("Evaluator", func() {
 
 				`"foo bar"`:     `"foo bar"`,
 				`"foo" + "bar"`: `"foobar"`,
+				`"" + "a"`:      `"a"`,
+
+				"1.5 + 1":  "2.5",
+				"1 / 2.0":  "0.5",
+				"-0.5 * 4": "-2.0",
+				"3 > 2.5":  "true",
+				"2.0 == 2": "true",
+
+				`[1, "a", [nil]]`:                      `[1, "a", [nil]]`,
+				"[1, 2 + 3][1]":                        "5",
+				"let a = [[1, 2]]; a[0][a[0][0]]":      "2",
+				"[fn(x) { x * 2 }][0](2)":              "4",
+				"[1] + [2, 3]":                         "[1, 2, 3]",
+				"[1, [2]] == [1, [2]]":                 "true",
+				`{"a": 1, 2: [true], false: {}}`:       `{"a": 1, 2: [true], false: {}}`,
+				`{"a": 1, "a": 2}`:                     `{"a": 2}`,
+				`let h = {"a": {"b": 1}}; h["a"]["b"]`: "1",
+				`{"a": 1}["b"]`:                        "nil",
+				`{"a": 1, "b": 2} == {"b": 2, "a": 1}`: "true",
+				`{"a": 1} == {"a": "1"}`:               "false",
+				"[1] != [\"1\"]":                       "true",
+
+				`try { throw "boom" } catch (e) { e }`:                                  "Error: boom",
+				"try { 1 / 0 } catch (e) { [e.kind, e.message] }":                       `["DivisionByZero", "division by zero"]`,
+				"try { 1(2) } catch (e) { e.kind }":                                     `"NotCallable"`,
+				"try { throw 1 } catch (e) { e.kind }":                                  `"WrongArgumentType"`,
+				"try { 1 } catch (e) { 2 }":                                             "1",
+				"let f = fn() { try { return 1 } finally { 2 } }; f()":                  "1",
+				"let f = fn() { try { 1 } finally { return 2 } }; f()":                  "2",
+				`try { try { throw "a" } finally { 1 } } catch (e) { e.message }`:       `"a"`,
+				`try { try { throw "a" } catch (e) { throw e } } catch (e) { e.stack }`: `["main 1:13"]`,
+				"let f = fn() { 1 / 0 }; try { f() } catch (e) { e.stack }":             `["main 1:31", "f 1:16"]`,
 			}
 
 			for input, output := range cases {
@@ -107,6 +155,24 @@ var _ = Describe("Evaluator", func() {
 				"true != 1": obj.ErrWronArgumentType,
 
 				"if (10 < 1) { 10 } else { true + true }": obj.ErrUndefinedMethod,
+
+				"1(2)":                         obj.ErrNotCallable,
+				"fn(a) { a }(1, 2)":            obj.ErrWrongNumberOfArguments,
+				"let f = fn(a, b) { a }; f(1)": obj.ErrWrongNumberOfArguments,
+
+				`import "a"`: evaluator.ErrImportsDisabled,
+				"1.a":        obj.ErrNoMembers,
+				`1 =~ "a"`:   obj.ErrWronArgumentType,
+				`"a" =~ 1`:   obj.ErrWronArgumentType,
+
+				"[1][1]":             obj.ErrIndexOutOfRange,
+				"[1][true]":          obj.ErrWronArgumentType,
+				"1[0]":               obj.ErrUndefinedMethod,
+				"{[1]: 2}":           obj.ErrUnhashable,
+				"{1: 2}[fn() { 1 }]": obj.ErrUnhashable,
+
+				"try { 1 / 0 } finally { 1 }":  obj.ErrDevisionByZero,
+				"try { 1 } catch (e) { 2 }; e": obj.ErrUnknownIdentifier,
 			}
 
 			for input, resultErr := range cases {
@@ -118,5 +184,158 @@ var _ = Describe("Evaluator", func() {
 				})
 			}
 		})
+
+		Context("when calling a builtin", func() {
+			It("passes arguments and returns the result", func() {
+				env := obj.NewEnv()
+				env.Set("len", obj.Builtin{Name: "len", Fn: func(args ...obj.Object) (obj.Object, error) {
+					return obj.New[obj.Integer](int64(len(args[0].(obj.String).Value()))), nil
+				}})
+
+				result, err := evaluator.New().Eval(lo.Must(parser.New(lexer.New(`len("foo") + 1`)).ParseProgram()), env)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Inspect()).To(Equal("4"))
+			})
+		})
+
+		Context("when builtins are provided", func() {
+			option := evaluator.WithBuiltins(func(evaluator.Evaluator) map[string]obj.Object {
+				return map[string]obj.Object{"one": obj.New[obj.Integer](1)}
+			})
+
+			It("resolves them after bindings of the program", func() {
+				program := lo.Must(parser.New(lexer.New("let a = one; let f = fn(one) { one }; [a, f(2)]")).ParseProgram())
+
+				result, err := evaluator.New(option).Eval(program)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Inspect()).To(Equal("[1, 2]"))
+				Expect(evaluator.New(option).Builtins()).To(Equal([]string{"one"}))
+			})
+		})
+
+		Context("when the context is canceled", func() {
+			It("fails on the next function call", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				program := lo.Must(parser.New(lexer.New("let f = fn() { 1 }; f()")).ParseProgram())
+
+				_, err := evaluator.New(evaluator.WithContext(ctx)).Eval(program)
+				Expect(err).To(MatchError(context.Canceled))
+			})
+
+			It("can't be caught", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				program := lo.Must(parser.New(lexer.New("let f = fn() { 1 }; try { f() } catch (e) { 2 }")).ParseProgram())
+
+				_, err := evaluator.New(evaluator.WithContext(ctx)).Eval(program)
+				Expect(err).To(MatchError(context.Canceled))
+			})
+		})
+
+		Context("when an error is not caught", func() {
+			It("returns the Error", func() {
+				program := lo.Must(parser.New(lexer.New("let f = fn() {\n  throw \"boom\"\n}; f()")).ParseProgram())
+
+				_, err := evaluator.New().Eval(program)
+
+				var thrown obj.Error
+
+				Expect(errors.As(err, &thrown)).To(BeTrue())
+				Expect(thrown.Inspect()).To(Equal("Error: boom"))
+				Expect(thrown.Stack).To(Equal([]string{"main 3:4", "f 2:3"}))
+			})
+
+			It("names kinds of host errors", func() {
+				errCustom := errors.New("custom")
+				env := obj.NewEnv()
+				env.Set("fail", obj.Builtin{Name: "fail", Fn: func(...obj.Object) (obj.Object, error) {
+					return obj.NIL, fmt.Errorf("fail: %w", errCustom)
+				}})
+
+				program := lo.Must(parser.New(lexer.New("try { fail() } catch (e) { e.kind + \" \" + e.message }")).ParseProgram())
+
+				result, err := evaluator.New(evaluator.WithErrorKind(errCustom, "Custom")).Eval(program, env)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Inspect()).To(Equal(`"Custom fail: custom"`))
+
+				_, err = evaluator.New().Eval(lo.Must(parser.New(lexer.New("fail()")).ParseProgram()), env)
+				Expect(err).To(MatchError(errCustom))
+			})
+		})
+
+		Context("when a hook fails", func() {
+			It("can't be caught", func() {
+				errStop := errors.New("stop")
+				program := lo.Must(parser.New(lexer.New("try { 1; 2 } catch (e) { 3 } finally { 4 }")).ParseProgram())
+
+				_, err := evaluator.New(evaluator.WithHook(func(event evaluator.Event) error {
+					if event.Depth == 0 && event.Node.Pos().Column == 7 {
+						return errStop
+					}
+
+					return nil
+				})).Eval(program)
+				Expect(err).To(MatchError(errStop))
+			})
+		})
+
+		Context("when importing a module provided by the host", func() {
+			calls := 0
+
+			option := evaluator.WithModule("consts", func(evaluator.Evaluator) obj.Module {
+				calls++
+
+				return obj.NewModule("consts", map[string]obj.Object{"one": obj.New[obj.Integer](1)})
+			})
+
+			BeforeEach(func() {
+				calls = 0
+			})
+
+			It("binds the module without an importer and creates it once", func() {
+				program := lo.Must(parser.New(lexer.New(`import "consts"; import { one } from "consts"; consts.one + one`)).ParseProgram())
+
+				result, err := evaluator.New(option).Eval(program)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Inspect()).To(Equal("2"))
+				Expect(calls).To(Equal(1))
+			})
+
+			It("fails to import other modules", func() {
+				_, err := evaluator.New(option).Eval(lo.Must(parser.New(lexer.New(`import "./consts"`)).ParseProgram()))
+				Expect(err).To(MatchError(evaluator.ErrImportsDisabled))
+			})
+		})
+	})
+})
+
+var _ = Describe("Evaluator.Regexp", func() {
+	It("caches compiled patterns", func() {
+		e := evaluator.New()
+
+		Expect(lo.Must(e.Regexp("a+"))).To(BeIdenticalTo(lo.Must(e.Regexp("a+"))))
+		Expect(lo.Must(e.Regexp("a+"))).ToNot(BeIdenticalTo(lo.Must(evaluator.New().Regexp("a+"))))
+	})
+
+	It("evicts the least recently used patterns", func() {
+		e := evaluator.New()
+		first := lo.Must(e.Regexp("a+"))
+		recent := lo.Must(e.Regexp("b+"))
+
+		for i := range 1000 {
+			lo.Must(e.Regexp(fmt.Sprintf("c{%d}", i)))
+			lo.Must(e.Regexp("b+"))
+		}
+
+		Expect(lo.Must(e.Regexp("a+"))).ToNot(BeIdenticalTo(first))
+		Expect(lo.Must(e.Regexp("b+"))).To(BeIdenticalTo(recent))
+	})
+
+	It("fails on invalid patterns", func() {
+		_, err := evaluator.New().Regexp("(")
+		Expect(err).To(MatchError("error parsing regexp: missing closing ): `(`"))
 	})
 })
@@ -0,0 +1,14 @@
+package evaluator
+
+// WithFileRoots grants the standard library access to files in the directories and their subdirectories.
+// By default scripts can't access files.
+func WithFileRoots(roots ...string) Option {
+	return func(s *state) {
+		s.fileRoots = append(s.fileRoots, roots...)
+	}
+}
+
+// FileRoots returns directories granted with WithFileRoots.
+func (e Evaluator) FileRoots() []string {
+	return e.fileRoots
+}
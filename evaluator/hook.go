@@ -0,0 +1,115 @@
+package evaluator
+
+import (
+	"github.com/zhulik/monkey/ast"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+const (
+	mainFrame      = "main"
+	anonymousFrame = "<anonymous>"
+)
+
+// Event describes the statement which is about to be evaluated.
+type Event struct {
+	Node  ast.Statement
+	Env   obj.EnvGetSetter
+	Depth int    // number of function calls on the stack, 0 at the top level
+	File  string // file of the module the statement belongs to, empty if the program is not read from a file
+}
+
+// Hook is called before each statement is evaluated, an error returned by the hook aborts the evaluation,
+// scripts can't catch it.
+type Hook func(event Event) error
+
+// BranchHook is called when an if expression chooses a branch, then is true when the Then block is taken.
+type BranchHook func(node *ast.IfExpression, then bool)
+
+// Frame is an entry of the call stack.
+type Frame struct {
+	Name     string
+	Function *ast.FunctionExpression // nil for the main frame
+	Node     ast.Statement           // the statement being evaluated, nil until the first one starts
+	File     string                  // file of the module the statement belongs to
+	Env      obj.EnvGetSetter
+}
+
+// Tracer is notified when a frame is pushed to and popped from the call stack, the main frame is pushed
+// when a program is evaluated.
+type Tracer interface {
+	Enter(frame Frame)
+	Exit(frame Frame)
+}
+
+// WithHook adds the hook, hooks are called in the order they were added.
+func WithHook(hook Hook) Option {
+	return func(s *state) {
+		s.hooks = append(s.hooks, hook)
+	}
+}
+
+func WithBranchHook(hook BranchHook) Option {
+	return func(s *state) {
+		s.branchHooks = append(s.branchHooks, hook)
+	}
+}
+
+func WithTracer(tracer Tracer) Option {
+	return func(s *state) {
+		s.tracers = append(s.tracers, tracer)
+	}
+}
+
+// Stack returns a copy of the call stack, the main frame goes first.
+func (e Evaluator) Stack() []Frame {
+	stack := make([]Frame, len(e.frames))
+	copy(stack, e.frames)
+
+	return stack
+}
+
+func (e Evaluator) evalStatement(node ast.Statement, env obj.EnvGetSetter) (obj.Object, error) {
+	frame := &e.frames[len(e.frames)-1]
+	frame.Node = node
+	frame.File = e.file
+	frame.Env = env
+
+	for _, hook := range e.hooks {
+		err := hook(Event{Node: node, Env: env, Depth: len(e.frames) - 1, File: e.file})
+		if err != nil {
+			return nil, hookError{err}
+		}
+	}
+
+	result, err := e.Eval(node, env)
+	if err != nil {
+		return nil, e.catchable(err)
+	}
+
+	return result, nil
+}
+
+// call pushes the frame for the duration of a function call, the returned function pops it.
+func (e Evaluator) call(frame Frame) func() {
+	e.frames = append(e.frames, frame)
+	exit := e.notify(frame)
+
+	return func() {
+		exit()
+
+		e.frames = e.frames[:len(e.frames)-1]
+	}
+}
+
+// notify tells tracers the frame was entered, the returned function tells them it was exited.
+func (e Evaluator) notify(frame Frame) func() {
+	for _, tracer := range e.tracers {
+		tracer.Enter(frame)
+	}
+
+	return func() {
+		for _, tracer := range e.tracers {
+			tracer.Exit(frame)
+		}
+	}
+}
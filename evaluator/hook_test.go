@@ -0,0 +1,104 @@
+package evaluator_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/evaluator"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/parser"
+)
+
+var _ = Describe("Hook", func() {
+	source := "let add = fn(a, b) {\n  a + b\n};\nlet x = add(1, 2);\nfn() { x }()"
+
+	run := func(hook func(evaluator.Evaluator, evaluator.Event) error) error {
+		var eval evaluator.Evaluator
+
+		eval = evaluator.New(evaluator.WithHook(func(event evaluator.Event) error {
+			return hook(eval, event)
+		}))
+
+		_, err := eval.Eval(lo.Must(parser.New(lexer.New(source)).ParseProgram()))
+
+		return err
+	}
+
+	It("is called before each statement", func() {
+		events := []string{}
+
+		err := run(func(_ evaluator.Evaluator, event evaluator.Event) error {
+			events = append(events, fmt.Sprintf("%s %d %s", event.Node.Pos(), event.Depth, event.Node))
+
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(events).To(Equal([]string{
+			"1:1 0 let add = fn(a, b) { (a + b) };",
+			"4:1 0 let x = add(1, 2);",
+			"2:3 1 (a + b)",
+			"5:1 0 fn() { x }()",
+			"5:8 1 x",
+		}))
+	})
+
+	It("gives access to the environment and the call stack", func() {
+		stacks := []string{}
+
+		err := run(func(eval evaluator.Evaluator, event evaluator.Event) error {
+			if event.Depth == 0 {
+				return nil
+			}
+
+			a := "-"
+			if value, err := event.Env.Get("a"); err == nil {
+				a = value.Inspect()
+			}
+
+			stacks = append(stacks, fmt.Sprintf("%v %v", lo.Map(eval.Stack(), func(frame evaluator.Frame, _ int) string {
+				return fmt.Sprintf("%s@%s", frame.Name, frame.Node.Pos())
+			}), a))
+
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(stacks).To(Equal([]string{
+			"[main@4:1 add@2:3] 1",
+			"[main@5:1 <anonymous>@5:8] -",
+		}))
+	})
+
+	It("aborts evaluation when the hook fails", func() {
+		errStop := errors.New("stop")
+
+		err := run(func(_ evaluator.Evaluator, event evaluator.Event) error {
+			if event.Depth > 0 {
+				return errStop
+			}
+
+			return nil
+		})
+		Expect(err).To(MatchError(errStop))
+	})
+})
+
+var _ = Describe("BranchHook", func() {
+	It("is called with the chosen branch", func() {
+		branches := []string{}
+
+		eval := evaluator.New(evaluator.WithBranchHook(func(node *ast.IfExpression, then bool) {
+			branches = append(branches, fmt.Sprintf("%s %t", node.Pos(), then))
+		}))
+
+		_, err := eval.Eval(lo.Must(parser.New(lexer.New("if (true) { 1 }; if (1 > 2) { 1 } else { 2 }")).ParseProgram()))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(branches).To(Equal([]string{"1:1 true", "1:18 false"}))
+	})
+})
@@ -0,0 +1,111 @@
+package evaluator
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/zhulik/monkey/ast"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+// Importer loads modules for import statements, the path is resolved relative to the evaluator's file.
+type Importer interface {
+	Import(evaluator Evaluator, path string) (obj.Module, error)
+}
+
+// WithImporter enables import statements, without an importer they fail with ErrImportsDisabled.
+func WithImporter(importer Importer) Option {
+	return func(s *state) {
+		s.importer = importer
+	}
+}
+
+// ModuleFunc creates a module provided by the host, it's called once per evaluator on the first import of the module.
+type ModuleFunc func(evaluator Evaluator) obj.Module
+
+// WithModule provides a module imported by the exact name, for instance `import "strings"`. Modules provided
+// by the host take precedence over the importer and are available even if imports of files are disabled.
+func WithModule(name string, module ModuleFunc) Option {
+	return func(s *state) {
+		s.modules[name] = module
+	}
+}
+
+// EvalModule evaluates the program of the module file in a new global environment, a frame named after the file
+// is pushed meanwhile. Top level let statements marked with export are exported.
+func (e Evaluator) EvalModule(file string, program *ast.Program) (obj.Module, error) {
+	e.file = file
+	env := obj.NewEnv()
+
+	exit := e.call(Frame{Name: file})
+
+	_, err := e.evalProgram(program, env)
+
+	exit()
+
+	if err != nil {
+		return obj.Module{}, err
+	}
+
+	exports := []string{}
+
+	for _, stmt := range program.Statements {
+		if export, ok := stmt.(*ast.ExportStatement); ok {
+			exports = append(exports, export.V.Name.V)
+		}
+	}
+
+	slices.Sort(exports)
+
+	base := filepath.Base(file)
+
+	return obj.Module{
+		Name:    strings.TrimSuffix(base, filepath.Ext(base)),
+		Path:    file,
+		Env:     env,
+		Exports: slices.Compact(exports),
+	}, nil
+}
+
+func (e Evaluator) evalImportStatement(node *ast.ImportStatement, env obj.EnvGetSetter) (obj.Object, error) {
+	module, err := e.importModule(node.V.V)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	if node.Name != nil {
+		env.Set(node.Name.V, module)
+
+		return obj.NIL, nil
+	}
+
+	for _, name := range node.Names {
+		value, mErr := module.Member(name.V)
+		if mErr != nil {
+			return obj.NIL, mErr //nolint:wrapcheck
+		}
+
+		env.Set(name.V, value)
+	}
+
+	return obj.NIL, nil
+}
+
+func (e Evaluator) importModule(path string) (obj.Module, error) {
+	if module, ok := e.loaded[path]; ok {
+		return module, nil
+	}
+
+	if fn, ok := e.modules[path]; ok {
+		e.loaded[path] = fn(e)
+
+		return e.loaded[path], nil
+	}
+
+	if e.importer == nil {
+		return obj.Module{}, ErrImportsDisabled
+	}
+
+	return e.importer.Import(e, path) //nolint:wrapcheck
+}
@@ -0,0 +1,83 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+var ErrIndexOutOfRange = errors.New("index out of range")
+
+// Array is an immutable list of objects, operators return new arrays.
+type Array struct {
+	BaseObject[[]Object]
+}
+
+func (o Array) TypeName() string {
+	return "Array"
+}
+
+func (o Array) Inspect() string {
+	elements := lo.Map(o.value, func(item Object, _ int) string {
+		return item.Inspect()
+	})
+
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+func (o Array) OperatorIndex(index Object) (Object, error) {
+	i, ok := index.(Integer)
+	if !ok {
+		return NIL, ErrWronArgumentType
+	}
+
+	if i.value < 0 || i.value >= int64(len(o.value)) {
+		return NIL, fmt.Errorf("%w: %d, length %d", ErrIndexOutOfRange, i.value, len(o.value))
+	}
+
+	return o.value[i.value], nil
+}
+
+func (o Array) OperatorPlus(other Object) (Object, error) {
+	otherArray, ok := other.(Array)
+	if !ok {
+		return NIL, ErrWronArgumentType
+	}
+
+	return New[Array](append(o.value[:len(o.value):len(o.value)], otherArray.value...)), nil
+}
+
+func (o Array) OperatorEQ(other Object) (Object, error) {
+	otherArray, ok := other.(Array)
+	if !ok {
+		return NIL, ErrWronArgumentType
+	}
+
+	return ToBoolean(o.equal(otherArray)), nil
+}
+
+func (o Array) OperatorNEQ(other Object) (Object, error) {
+	otherArray, ok := other.(Array)
+	if !ok {
+		return NIL, ErrWronArgumentType
+	}
+
+	return ToBoolean(!o.equal(otherArray)), nil
+}
+
+// equal compares elements pairwise, elements of different types are not equal.
+func (o Array) equal(other Array) bool {
+	if len(o.value) != len(other.value) {
+		return false
+	}
+
+	for i, element := range o.value {
+		if !Equal(element, other.value[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,62 @@
+package object_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+var _ = Describe("Array", func() {
+	array := obj.New[obj.Array]([]obj.Object{obj.New[obj.Integer](1), obj.New[obj.String]("a"), obj.NIL})
+
+	Describe(".Inspect", func() {
+		It("returns string representation", func() {
+			Expect(array.Inspect()).To(Equal(`[1, "a", nil]`))
+		})
+	})
+
+	Describe(".OperatorIndex", func() {
+		It("returns the element", func() {
+			Expect(array.OperatorIndex(obj.New[obj.Integer](1))).To(Equal(obj.New[obj.String]("a")))
+		})
+
+		It("fails when the index is out of range", func() {
+			_, err := array.OperatorIndex(obj.New[obj.Integer](-1))
+			Expect(err).To(MatchError("index out of range: -1, length 3"))
+		})
+	})
+
+	Describe(".OperatorPlus", func() {
+		It("does not modify operands", func() {
+			left := obj.New[obj.Array](make([]obj.Object, 1, 2))
+			left.Value()[0] = obj.TRUE
+
+			first, err := left.OperatorPlus(obj.New[obj.Array]([]obj.Object{obj.New[obj.Integer](1)}))
+			Expect(err).ToNot(HaveOccurred())
+
+			second, err := left.OperatorPlus(obj.New[obj.Array]([]obj.Object{obj.New[obj.Integer](2)}))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(first.Inspect()).To(Equal("[true, 1]"))
+			Expect(second.Inspect()).To(Equal("[true, 2]"))
+		})
+	})
+
+	Describe(".OperatorEQ", func() {
+		cases := map[string]struct {
+			other    obj.Array
+			expected obj.Boolean
+		}{
+			"equal elements":     {obj.New[obj.Array]([]obj.Object{obj.New[obj.Integer](1), obj.New[obj.String]("a"), obj.NIL}), obj.TRUE},
+			"different elements": {obj.New[obj.Array]([]obj.Object{obj.New[obj.Integer](1), obj.New[obj.String]("b"), obj.NIL}), obj.FALSE},
+			"different types":    {obj.New[obj.Array]([]obj.Object{obj.New[obj.Integer](1), obj.New[obj.Integer](1), obj.NIL}), obj.FALSE},
+			"different lengths":  {obj.New[obj.Array]([]obj.Object{obj.New[obj.Integer](1)}), obj.FALSE},
+		}
+
+		for name, c := range cases {
+			It("compares arrays with "+name, func() {
+				Expect(array.OperatorEQ(c.other)).To(Equal(c.expected))
+			})
+		}
+	})
+})
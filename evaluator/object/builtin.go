@@ -0,0 +1,32 @@
+package object
+
+import "errors"
+
+var (
+	ErrNotCallable            = errors.New("object is not callable")
+	ErrWrongNumberOfArguments = errors.New("wrong number of arguments")
+)
+
+// Callable is implemented by objects which can be called from Monkey code.
+type Callable interface {
+	Object
+	Call(args ...Object) (Object, error)
+}
+
+// Builtin is a function implemented in Go.
+type Builtin struct {
+	Name string
+	Fn   func(args ...Object) (Object, error)
+}
+
+func (o Builtin) TypeName() string {
+	return "Builtin"
+}
+
+func (o Builtin) Inspect() string {
+	return "builtin " + o.Name
+}
+
+func (o Builtin) Call(args ...Object) (Object, error) {
+	return o.Fn(args...)
+}
@@ -0,0 +1,25 @@
+package object_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+var _ = Describe("Builtin", func() {
+	double := obj.Builtin{Name: "double", Fn: func(args ...obj.Object) (obj.Object, error) {
+		return args[0].(obj.Integer).OperatorPlus(args[0])
+	}}
+
+	Describe(".Inspect", func() {
+		It("returns the name", func() {
+			Expect(double.Inspect()).To(Equal("builtin double"))
+		})
+	})
+
+	Describe(".Call", func() {
+		It("calls the Go function", func() {
+			Expect(double.Call(obj.New[obj.Integer](2))).To(Equal(obj.New[obj.Integer](4)))
+		})
+	})
+})
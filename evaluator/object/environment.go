@@ -3,6 +3,9 @@ package object
 import (
 	"errors"
 	"fmt"
+	"slices"
+
+	"github.com/samber/lo"
 )
 
 var ErrUnknownIdentifier = errors.New("identifier is unknown")
@@ -56,3 +59,15 @@ func (e *Env) Set(name string, object Object) Object {
 
 	return object
 }
+
+func (e Env) Parent() EnvGetter {
+	return e.parent
+}
+
+// Names returns sorted names bound in this environment, parents are not included.
+func (e Env) Names() []string {
+	names := lo.Keys(e.store)
+	slices.Sort(names)
+
+	return names
+}
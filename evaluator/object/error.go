@@ -0,0 +1,53 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/samber/lo"
+)
+
+var ErrUnknownMember = errors.New("unknown member")
+
+// Error is a value thrown with throw or a runtime error caught by try, it's also a Go error so it travels
+// through the host code as any other error. Kind names the class of the error, for instance DivisionByZero,
+// Stack lists the frames where the error was raised as "name line:column", the main frame goes first.
+type Error struct {
+	Kind    string
+	Message string
+	Stack   []string
+	Cause   error // the host error the value was created from, nil for errors created in Monkey code
+}
+
+func (o Error) TypeName() string {
+	return "Error"
+}
+
+func (o Error) Inspect() string {
+	return o.Kind + ": " + o.Message
+}
+
+// Error returns the message, so host errors converted to Error values are reported as before.
+func (o Error) Error() string {
+	return o.Message
+}
+
+func (o Error) Unwrap() error {
+	return o.Cause
+}
+
+// Member returns kind, message and stack, an Array of Strings.
+func (o Error) Member(name string) (Object, error) {
+	switch name {
+	case "kind":
+		return New[String](o.Kind), nil
+	case "message":
+		return New[String](o.Message), nil
+	case "stack":
+		return New[Array](lo.Map(o.Stack, func(frame string, _ int) Object {
+			return New[String](frame)
+		})), nil
+	default:
+		return NIL, fmt.Errorf("%w: Error.%s", ErrUnknownMember, name)
+	}
+}
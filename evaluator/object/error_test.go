@@ -0,0 +1,47 @@
+package object_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+var _ = Describe("Error", func() {
+	err := obj.Error{
+		Kind:    "DivisionByZero",
+		Message: "division by zero",
+		Stack:   []string{"main 1:1", "div 2:3"},
+		Cause:   fmt.Errorf("wrapped: %w", obj.ErrDevisionByZero),
+	}
+
+	Describe(".Inspect", func() {
+		It("returns the kind and the message", func() {
+			Expect(err.Inspect()).To(Equal("DivisionByZero: division by zero"))
+		})
+	})
+
+	Describe(".Error", func() {
+		It("returns the message and unwraps to the cause", func() {
+			Expect(err).To(MatchError("division by zero"))
+			Expect(err).To(MatchError(obj.ErrDevisionByZero))
+		})
+	})
+
+	Describe(".Member", func() {
+		It("returns the kind, the message and the stack", func() {
+			Expect(err.Member("kind")).To(Equal(obj.New[obj.String]("DivisionByZero")))
+			Expect(err.Member("message")).To(Equal(obj.New[obj.String]("division by zero")))
+
+			stack, mErr := err.Member("stack")
+			Expect(mErr).ToNot(HaveOccurred())
+			Expect(stack.Inspect()).To(Equal(`["main 1:1", "div 2:3"]`))
+		})
+
+		It("fails on unknown members", func() {
+			_, mErr := err.Member("cause")
+			Expect(mErr).To(MatchError("unknown member: Error.cause"))
+		})
+	})
+})
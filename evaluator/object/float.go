@@ -0,0 +1,149 @@
+package object
+
+import (
+	"strconv"
+	"strings"
+)
+
+type Float struct {
+	BaseObject[float64]
+}
+
+func (o Float) TypeName() string {
+	return "Float"
+}
+
+// Inspect formats the float in the shortest decimal form which parses back to it, integral values get a .0 suffix.
+// Float literals have no exponent notation, so very small and very large values are printed with all their
+// digits. NaN and infinities are printed as NaN, +Inf and -Inf.
+func (o Float) Inspect() string {
+	result := strconv.FormatFloat(o.value, 'f', -1, 64)
+	if !strings.ContainsAny(result, ".IN") {
+		result += ".0"
+	}
+
+	return result
+}
+
+func (o Float) ToFloat() float64 {
+	return o.value
+}
+
+// operand converts the other operand of an arithmetic or comparison operator, numbers like Integers are promoted
+// to floats.
+func (o Float) operand(other Object) (float64, error) {
+	number, ok := other.(ToFloat)
+	if !ok {
+		return 0, ErrWronArgumentType
+	}
+
+	return number.ToFloat(), nil
+}
+
+func (o Float) OperatorPrefixMinus() (Object, error) {
+	o.value = -o.value
+
+	return o, nil
+}
+
+func (o Float) OperatorPlus(other Object) (Object, error) {
+	value, err := o.operand(other)
+	if err != nil {
+		return NIL, err
+	}
+
+	o.value += value
+
+	return o, nil
+}
+
+func (o Float) OperatorMinus(other Object) (Object, error) {
+	value, err := o.operand(other)
+	if err != nil {
+		return NIL, err
+	}
+
+	o.value -= value
+
+	return o, nil
+}
+
+func (o Float) OperatorAsterisk(other Object) (Object, error) {
+	value, err := o.operand(other)
+	if err != nil {
+		return NIL, err
+	}
+
+	o.value *= value
+
+	return o, nil
+}
+
+func (o Float) OperatorSlash(other Object) (Object, error) {
+	value, err := o.operand(other)
+	if err != nil {
+		return NIL, err
+	}
+
+	if value == 0 {
+		return Float{}, ErrDevisionByZero
+	}
+
+	o.value /= value
+
+	return o, nil
+}
+
+func (o Float) OperatorGT(other Object) (Object, error) {
+	value, err := o.operand(other)
+	if err != nil {
+		return NIL, err
+	}
+
+	return ToBoolean(o.value > value), nil
+}
+
+func (o Float) OperatorGTE(other Object) (Object, error) {
+	value, err := o.operand(other)
+	if err != nil {
+		return NIL, err
+	}
+
+	return ToBoolean(o.value >= value), nil
+}
+
+func (o Float) OperatorLT(other Object) (Object, error) {
+	value, err := o.operand(other)
+	if err != nil {
+		return NIL, err
+	}
+
+	return ToBoolean(o.value < value), nil
+}
+
+func (o Float) OperatorLTE(other Object) (Object, error) {
+	value, err := o.operand(other)
+	if err != nil {
+		return NIL, err
+	}
+
+	return ToBoolean(o.value <= value), nil
+}
+
+func (o Float) OperatorEQ(other Object) (Object, error) {
+	value, err := o.operand(other)
+	if err != nil {
+		return NIL, err
+	}
+
+	return ToBoolean(o.value == value), nil
+}
+
+func (o Float) OperatorNEQ(other Object) (Object, error) {
+	value, err := o.operand(other)
+	if err != nil {
+		return NIL, err
+	}
+
+	return ToBoolean(o.value != value), nil
+}
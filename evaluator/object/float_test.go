@@ -0,0 +1,45 @@
+package object_test
+
+import (
+	"math"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+var _ = Describe("Float", func() {
+	Describe(".Inspect", func() {
+		cases := map[float64]string{
+			1.5:          "1.5",
+			2:            "2.0",
+			-0.25:        "-0.25",
+			1e21:         "1000000000000000000000.0",
+			math.Inf(1):  "+Inf",
+			math.NaN():   "NaN",
+			math.Pi:      "3.141592653589793",
+			1_000_000.75: "1000000.75",
+			0.00001:      "0.00001",
+			-1.5e-7:      "-0.00000015",
+		}
+
+		for value, expected := range cases {
+			It("returns "+expected, func() {
+				Expect(obj.New[obj.Float](value).Inspect()).To(Equal(expected))
+			})
+		}
+	})
+
+	Describe("operators", func() {
+		It("promotes integers", func() {
+			Expect(obj.New[obj.Float](1.5).OperatorPlus(obj.New[obj.Integer](1))).To(Equal(obj.New[obj.Float](2.5)))
+			Expect(obj.New[obj.Integer](1).OperatorPlus(obj.New[obj.Float](1.5))).To(Equal(obj.New[obj.Float](2.5)))
+			Expect(obj.New[obj.Integer](2).OperatorEQ(obj.New[obj.Float](2))).To(Equal(obj.TRUE))
+		})
+
+		It("fails on division by zero", func() {
+			_, err := obj.New[obj.Float](1).OperatorSlash(obj.New[obj.Integer](0))
+			Expect(err).To(MatchError(obj.ErrDevisionByZero))
+		})
+	})
+})
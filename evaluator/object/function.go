@@ -6,12 +6,14 @@ import (
 
 type Evaluator interface {
 	Eval(node ast.Node, envs ...EnvGetSetter) (Object, error)
+	Call(function Function, args ...Object) (Object, error)
 }
 
 type Function struct {
 	Evaluator Evaluator
 	Function  *ast.FunctionExpression
 	Env       EnvGetSetter
+	Name      string // name of the binding the function was first assigned to, empty for anonymous functions
 }
 
 func (o Function) TypeName() string {
@@ -23,10 +25,11 @@ func (o Function) Inspect() string {
 }
 
 func (o Function) Call(args ...Object) (Object, error) {
-	return o.Evaluator.Eval(o.Function.V, o.addArgsToEnv(args)) //nolint:wrapcheck
+	return o.Evaluator.Call(o, args...) //nolint:wrapcheck
 }
 
-func (o Function) addArgsToEnv(args []Object) EnvGetSetter {
+// Bind returns the environment the function's body is evaluated in: the closure extended with the arguments.
+func (o Function) Bind(args []Object) EnvGetSetter {
 	closure := NewEnv(o.Env)
 	for i, val := range args {
 		closure.Set(o.Function.Arguments[i].V, val)
@@ -0,0 +1,181 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+var ErrUnhashable = errors.New("object can't be used as a hash key")
+
+// HashKey identifies keys of hashes, keys of different types are different even if their values are equal.
+type HashKey struct {
+	Type  string
+	Value any
+}
+
+// Hashable is implemented by objects which can be used as hash keys.
+type Hashable interface {
+	HashKey() HashKey
+}
+
+func (o Integer) HashKey() HashKey {
+	return HashKey{Type: o.TypeName(), Value: o.value}
+}
+
+func (s String) HashKey() HashKey {
+	return HashKey{Type: s.TypeName(), Value: s.value}
+}
+
+func (o Boolean) HashKey() HashKey {
+	return HashKey{Type: o.TypeName(), Value: o.value}
+}
+
+type HashPair struct {
+	Key   Object
+	Value Object
+}
+
+type hashData struct {
+	pairs []HashPair
+	index map[HashKey]int
+}
+
+// Hash maps hashable keys to values keeping the insertion order. Copies of a hash share its pairs.
+type Hash struct {
+	data *hashData
+}
+
+// NewHash creates a hash of the pairs, later pairs replace values of earlier pairs with equal keys.
+func NewHash(pairs ...HashPair) (Hash, error) {
+	hash := Hash{data: &hashData{index: map[HashKey]int{}}}
+
+	for _, pair := range pairs {
+		err := hash.Set(pair.Key, pair.Value)
+		if err != nil {
+			return Hash{}, err
+		}
+	}
+
+	return hash, nil
+}
+
+func (o Hash) TypeName() string {
+	return "Hash"
+}
+
+func (o Hash) Inspect() string {
+	pairs := lo.Map(o.Pairs(), func(pair HashPair, _ int) string {
+		return pair.Key.Inspect() + ": " + pair.Value.Inspect()
+	})
+
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// Pairs returns pairs of the hash in the insertion order.
+func (o Hash) Pairs() []HashPair {
+	if o.data == nil {
+		return nil
+	}
+
+	return o.data.pairs
+}
+
+func (o Hash) Len() int {
+	return len(o.Pairs())
+}
+
+// Get returns the value of the key, ok is false if the key is missing.
+func (o Hash) Get(key Object) (Object, bool, error) {
+	hashKey, err := hashKey(key)
+	if err != nil {
+		return NIL, false, err
+	}
+
+	if o.data == nil {
+		return NIL, false, nil
+	}
+
+	i, ok := o.data.index[hashKey]
+	if !ok {
+		return NIL, false, nil
+	}
+
+	return o.data.pairs[i].Value, true, nil
+}
+
+// Set sets the value of the key, it's meant for hosts building hashes, Monkey code can't modify hashes.
+func (o Hash) Set(key, value Object) error {
+	hashKey, err := hashKey(key)
+	if err != nil {
+		return err
+	}
+
+	if i, ok := o.data.index[hashKey]; ok {
+		o.data.pairs[i].Value = value
+
+		return nil
+	}
+
+	o.data.index[hashKey] = len(o.data.pairs)
+	o.data.pairs = append(o.data.pairs, HashPair{Key: key, Value: value})
+
+	return nil
+}
+
+// Same reports whether both hashes share their pairs.
+func (o Hash) Same(other Hash) bool {
+	return o.data == other.data
+}
+
+// OperatorIndex returns the value of the key or nil if the key is missing.
+func (o Hash) OperatorIndex(index Object) (Object, error) {
+	value, _, err := o.Get(index)
+
+	return value, err
+}
+
+func (o Hash) OperatorEQ(other Object) (Object, error) {
+	otherHash, ok := other.(Hash)
+	if !ok {
+		return NIL, ErrWronArgumentType
+	}
+
+	return ToBoolean(o.equal(otherHash)), nil
+}
+
+func (o Hash) OperatorNEQ(other Object) (Object, error) {
+	otherHash, ok := other.(Hash)
+	if !ok {
+		return NIL, ErrWronArgumentType
+	}
+
+	return ToBoolean(!o.equal(otherHash)), nil
+}
+
+// equal compares values of equal keys, the order of pairs doesn't matter.
+func (o Hash) equal(other Hash) bool {
+	if o.Len() != other.Len() {
+		return false
+	}
+
+	for _, pair := range o.Pairs() {
+		value, ok, _ := other.Get(pair.Key)
+		if !ok || !Equal(pair.Value, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hashKey(key Object) (HashKey, error) {
+	hashable, ok := key.(Hashable)
+	if !ok {
+		return HashKey{}, fmt.Errorf("%w: %s", ErrUnhashable, key.TypeName())
+	}
+
+	return hashable.HashKey(), nil
+}
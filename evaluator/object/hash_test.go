@@ -0,0 +1,48 @@
+package object_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+var _ = Describe("Hash", func() {
+	str := obj.New[obj.String]
+	integer := obj.New[obj.Integer]
+
+	var hash obj.Hash
+
+	BeforeEach(func() {
+		hash = lo.Must(obj.NewHash(
+			obj.HashPair{Key: str("b"), Value: integer(1)},
+			obj.HashPair{Key: integer(1), Value: str("a")},
+		))
+	})
+
+	Describe(".Inspect", func() {
+		It("returns pairs in the insertion order", func() {
+			Expect(hash.Inspect()).To(Equal(`{"b": 1, 1: "a"}`))
+		})
+	})
+
+	Describe(".Set", func() {
+		It("replaces values keeping the order", func() {
+			Expect(hash.Set(str("b"), obj.TRUE)).To(Succeed())
+			Expect(hash.Set(obj.FALSE, obj.NIL)).To(Succeed())
+
+			Expect(hash.Inspect()).To(Equal(`{"b": true, 1: "a", false: nil}`))
+		})
+
+		It("fails when the key is not hashable", func() {
+			Expect(hash.Set(obj.New[obj.Float](1), obj.NIL)).To(MatchError("object can't be used as a hash key: Float"))
+		})
+	})
+
+	Describe(".OperatorIndex", func() {
+		It("distinguishes keys of different types", func() {
+			Expect(hash.OperatorIndex(integer(1))).To(Equal(str("a")))
+			Expect(hash.OperatorIndex(str("1"))).To(Equal(obj.NIL))
+		})
+	})
+})
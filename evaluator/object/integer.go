@@ -23,6 +23,10 @@ func (o Integer) Inspect() string {
 }
 
 func (o Integer) OperatorPlus(other Object) (Object, error) {
+	if float, ok := o.promote(other); ok {
+		return float.OperatorPlus(other)
+	}
+
 	otherInt, ok := other.(Integer)
 	if !ok {
 		return NIL, ErrWronArgumentType
@@ -40,6 +44,10 @@ func (o Integer) OperatorPrefixMinus() (Object, error) {
 }
 
 func (o Integer) OperatorMinus(other Object) (Object, error) {
+	if float, ok := o.promote(other); ok {
+		return float.OperatorMinus(other)
+	}
+
 	otherInt, ok := other.(Integer)
 	if !ok {
 		return NIL, ErrWronArgumentType
@@ -51,6 +59,10 @@ func (o Integer) OperatorMinus(other Object) (Object, error) {
 }
 
 func (o Integer) OperatorAsterisk(other Object) (Object, error) {
+	if float, ok := o.promote(other); ok {
+		return float.OperatorAsterisk(other)
+	}
+
 	otherInt, ok := other.(Integer)
 	if !ok {
 		return NIL, ErrWronArgumentType
@@ -62,6 +74,10 @@ func (o Integer) OperatorAsterisk(other Object) (Object, error) {
 }
 
 func (o Integer) OperatorSlash(other Object) (Object, error) {
+	if float, ok := o.promote(other); ok {
+		return float.OperatorSlash(other)
+	}
+
 	otherInt, ok := other.(Integer)
 	if !ok {
 		return NIL, ErrWronArgumentType
@@ -77,6 +93,10 @@ func (o Integer) OperatorSlash(other Object) (Object, error) {
 }
 
 func (o Integer) OperatorGT(other Object) (Object, error) {
+	if float, ok := o.promote(other); ok {
+		return float.OperatorGT(other)
+	}
+
 	otherInt, ok := other.(Integer)
 	if !ok {
 		return NIL, ErrWronArgumentType
@@ -86,6 +106,10 @@ func (o Integer) OperatorGT(other Object) (Object, error) {
 }
 
 func (o Integer) OperatorGTE(other Object) (Object, error) {
+	if float, ok := o.promote(other); ok {
+		return float.OperatorGTE(other)
+	}
+
 	otherInt, ok := other.(Integer)
 	if !ok {
 		return NIL, ErrWronArgumentType
@@ -95,6 +119,10 @@ func (o Integer) OperatorGTE(other Object) (Object, error) {
 }
 
 func (o Integer) OperatorLT(other Object) (Object, error) {
+	if float, ok := o.promote(other); ok {
+		return float.OperatorLT(other)
+	}
+
 	otherInt, ok := other.(Integer)
 	if !ok {
 		return NIL, ErrWronArgumentType
@@ -104,6 +132,10 @@ func (o Integer) OperatorLT(other Object) (Object, error) {
 }
 
 func (o Integer) OperatorLTE(other Object) (Object, error) {
+	if float, ok := o.promote(other); ok {
+		return float.OperatorLTE(other)
+	}
+
 	otherInt, ok := other.(Integer)
 	if !ok {
 		return NIL, ErrWronArgumentType
@@ -113,6 +145,10 @@ func (o Integer) OperatorLTE(other Object) (Object, error) {
 }
 
 func (o Integer) OperatorEQ(other Object) (Object, error) {
+	if float, ok := o.promote(other); ok {
+		return float.OperatorEQ(other)
+	}
+
 	otherInt, ok := other.(Integer)
 	if !ok {
 		return NIL, ErrWronArgumentType
@@ -122,6 +158,10 @@ func (o Integer) OperatorEQ(other Object) (Object, error) {
 }
 
 func (o Integer) OperatorNEQ(other Object) (Object, error) {
+	if float, ok := o.promote(other); ok {
+		return float.OperatorNEQ(other)
+	}
+
 	otherInt, ok := other.(Integer)
 	if !ok {
 		return NIL, ErrWronArgumentType
@@ -129,3 +169,17 @@ func (o Integer) OperatorNEQ(other Object) (Object, error) {
 
 	return ToBoolean(o.value != otherInt.value), nil
 }
+
+func (o Integer) ToFloat() float64 {
+	return float64(o.value)
+}
+
+// promote converts the integer to a Float if the other operand is a Float, mixed arithmetic and comparisons
+// are performed on floats.
+func (o Integer) promote(other Object) (Float, bool) {
+	if _, ok := other.(Float); !ok {
+		return Float{}, false
+	}
+
+	return New[Float](o.ToFloat()), true
+}
@@ -0,0 +1,52 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrNotExported = errors.New("name is not exported")
+	ErrNoMembers   = errors.New("object has no members")
+)
+
+// Members is implemented by objects whose members are accessed with the . operator.
+type Members interface {
+	Member(name string) (Object, error)
+}
+
+// Module is an evaluated module, only exported names of its environment are accessible.
+type Module struct {
+	Name    string
+	Path    string // resolved path of the source, empty for modules provided by the host
+	Env     EnvGetter
+	Exports []string // sorted
+}
+
+func (o Module) TypeName() string {
+	return "Module"
+}
+
+func (o Module) Inspect() string {
+	return "module " + o.Name
+}
+
+func (o Module) Member(name string) (Object, error) {
+	if _, ok := slices.BinarySearch(o.Exports, name); !ok {
+		return NIL, fmt.Errorf("%w: %s.%s", ErrNotExported, o.Name, name)
+	}
+
+	return o.Env.Get(name) //nolint:wrapcheck
+}
+
+// NewModule creates a module provided by the host, all members are exported.
+func NewModule(name string, members map[string]Object) Module {
+	env := NewEnv()
+
+	for member, value := range members {
+		env.Set(member, value)
+	}
+
+	return Module{Name: name, Env: env, Exports: env.Names()}
+}
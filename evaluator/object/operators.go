@@ -59,6 +59,15 @@ type OperatorSlash interface {
 	OperatorSlash(other Object) (Object, error)
 }
 
+type OperatorIndex interface {
+	OperatorIndex(index Object) (Object, error)
+}
+
+// Conversions.
+type ToFloat interface {
+	ToFloat() float64
+}
+
 func CastOperator[O any](obj Object) (O, error) {
 	op, ok := obj.(O)
 	if !ok {
@@ -67,3 +76,15 @@ func CastOperator[O any](obj Object) (O, error) {
 
 	return op, nil
 }
+
+// Equal reports whether the objects are equal according to the == operator, objects of different types are not equal.
+func Equal(a, b Object) bool {
+	op, err := CastOperator[OperatorEQ](a)
+	if err != nil {
+		return false
+	}
+
+	result, err := op.OperatorEQ(b)
+
+	return err == nil && result == TRUE
+}
@@ -0,0 +1,16 @@
+package object
+
+import "regexp"
+
+// Regex is a compiled regular expression with the syntax of Go's regexp package.
+type Regex struct {
+	BaseObject[*regexp.Regexp]
+}
+
+func (o Regex) TypeName() string {
+	return "Regex"
+}
+
+func (o Regex) Inspect() string {
+	return "regex " + New[String](o.value.String()).Inspect()
+}
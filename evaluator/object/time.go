@@ -0,0 +1,179 @@
+package object
+
+import "time"
+
+// Time is an instant with a location, times in different locations are equal if they are the same instant.
+type Time struct {
+	BaseObject[time.Time]
+}
+
+func (o Time) TypeName() string {
+	return "Time"
+}
+
+func (o Time) Inspect() string {
+	return o.value.Format(time.RFC3339Nano)
+}
+
+// OperatorPlus adds a Duration to the time.
+func (o Time) OperatorPlus(other Object) (Object, error) {
+	d, ok := other.(Duration)
+	if !ok {
+		return NIL, ErrWronArgumentType
+	}
+
+	o.value = o.value.Add(d.value)
+
+	return o, nil
+}
+
+// OperatorMinus subtracts a Duration from the time or returns the Duration between two times.
+func (o Time) OperatorMinus(other Object) (Object, error) {
+	switch other := other.(type) {
+	case Duration:
+		o.value = o.value.Add(-other.value)
+
+		return o, nil
+	case Time:
+		return New[Duration](o.value.Sub(other.value)), nil
+	default:
+		return NIL, ErrWronArgumentType
+	}
+}
+
+func (o Time) OperatorLT(other Object) (Object, error) {
+	return o.compare(other, time.Time.Before)
+}
+
+func (o Time) OperatorLTE(other Object) (Object, error) {
+	return o.compare(other, func(a, b time.Time) bool { return !a.After(b) })
+}
+
+func (o Time) OperatorGT(other Object) (Object, error) {
+	return o.compare(other, time.Time.After)
+}
+
+func (o Time) OperatorGTE(other Object) (Object, error) {
+	return o.compare(other, func(a, b time.Time) bool { return !a.Before(b) })
+}
+
+func (o Time) OperatorEQ(other Object) (Object, error) {
+	return o.compare(other, time.Time.Equal)
+}
+
+func (o Time) OperatorNEQ(other Object) (Object, error) {
+	return o.compare(other, func(a, b time.Time) bool { return !a.Equal(b) })
+}
+
+func (o Time) compare(other Object, cmp func(a, b time.Time) bool) (Object, error) {
+	otherTime, ok := other.(Time)
+	if !ok {
+		return NIL, ErrWronArgumentType
+	}
+
+	return ToBoolean(cmp(o.value, otherTime.value)), nil
+}
+
+// Duration is the elapsed time between two instants with nanosecond precision.
+type Duration struct {
+	BaseObject[time.Duration]
+}
+
+func (o Duration) TypeName() string {
+	return "Duration"
+}
+
+func (o Duration) Inspect() string {
+	return o.value.String()
+}
+
+func (o Duration) OperatorPrefixMinus() (Object, error) {
+	o.value = -o.value
+
+	return o, nil
+}
+
+func (o Duration) OperatorPlus(other Object) (Object, error) {
+	switch other := other.(type) {
+	case Duration:
+		o.value += other.value
+
+		return o, nil
+	case Time:
+		return other.OperatorPlus(o)
+	default:
+		return NIL, ErrWronArgumentType
+	}
+}
+
+func (o Duration) OperatorMinus(other Object) (Object, error) {
+	otherDuration, ok := other.(Duration)
+	if !ok {
+		return NIL, ErrWronArgumentType
+	}
+
+	o.value -= otherDuration.value
+
+	return o, nil
+}
+
+// OperatorAsterisk multiplies the duration by an Integer.
+func (o Duration) OperatorAsterisk(other Object) (Object, error) {
+	n, ok := other.(Integer)
+	if !ok {
+		return NIL, ErrWronArgumentType
+	}
+
+	o.value *= time.Duration(n.value)
+
+	return o, nil
+}
+
+// OperatorSlash divides the duration by an Integer.
+func (o Duration) OperatorSlash(other Object) (Object, error) {
+	n, ok := other.(Integer)
+	if !ok {
+		return NIL, ErrWronArgumentType
+	}
+
+	if n.value == 0 {
+		return NIL, ErrDevisionByZero
+	}
+
+	o.value /= time.Duration(n.value)
+
+	return o, nil
+}
+
+func (o Duration) OperatorLT(other Object) (Object, error) {
+	return o.compare(other, func(a, b time.Duration) bool { return a < b })
+}
+
+func (o Duration) OperatorLTE(other Object) (Object, error) {
+	return o.compare(other, func(a, b time.Duration) bool { return a <= b })
+}
+
+func (o Duration) OperatorGT(other Object) (Object, error) {
+	return o.compare(other, func(a, b time.Duration) bool { return a > b })
+}
+
+func (o Duration) OperatorGTE(other Object) (Object, error) {
+	return o.compare(other, func(a, b time.Duration) bool { return a >= b })
+}
+
+func (o Duration) OperatorEQ(other Object) (Object, error) {
+	return o.compare(other, func(a, b time.Duration) bool { return a == b })
+}
+
+func (o Duration) OperatorNEQ(other Object) (Object, error) {
+	return o.compare(other, func(a, b time.Duration) bool { return a != b })
+}
+
+func (o Duration) compare(other Object, cmp func(a, b time.Duration) bool) (Object, error) {
+	otherDuration, ok := other.(Duration)
+	if !ok {
+		return NIL, ErrWronArgumentType
+	}
+
+	return ToBoolean(cmp(o.value, otherDuration.value)), nil
+}
@@ -0,0 +1,66 @@
+package object_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+var _ = Describe("Time", func() {
+	utc := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	t := obj.New[obj.Time](utc)
+	hour := obj.New[obj.Duration](time.Hour)
+
+	Describe(".Inspect", func() {
+		It("returns RFC3339 with fractional seconds if present", func() {
+			Expect(t.Inspect()).To(Equal("2024-03-01T12:30:00Z"))
+			Expect(obj.New[obj.Time](utc.Add(time.Millisecond)).Inspect()).To(Equal("2024-03-01T12:30:00.001Z"))
+		})
+	})
+
+	Describe("operators", func() {
+		It("adds and subtracts durations", func() {
+			Expect(t.OperatorPlus(hour)).To(Equal(obj.New[obj.Time](utc.Add(time.Hour))))
+			Expect(hour.OperatorPlus(t)).To(Equal(obj.New[obj.Time](utc.Add(time.Hour))))
+			Expect(t.OperatorMinus(hour)).To(Equal(obj.New[obj.Time](utc.Add(-time.Hour))))
+		})
+
+		It("returns durations between times", func() {
+			later := obj.New[obj.Time](utc.Add(90 * time.Minute))
+
+			Expect(later.OperatorMinus(t)).To(Equal(obj.New[obj.Duration](90 * time.Minute)))
+		})
+
+		It("compares instants regardless of zones", func() {
+			berlin := obj.New[obj.Time](utc.In(time.FixedZone("CET", 3600)))
+
+			Expect(t.OperatorEQ(berlin)).To(Equal(obj.TRUE))
+			Expect(t.OperatorLT(berlin)).To(Equal(obj.FALSE))
+			Expect(t.OperatorLTE(berlin)).To(Equal(obj.TRUE))
+		})
+
+		It("fails on other types", func() {
+			_, err := t.OperatorLT(obj.New[obj.Integer](1))
+			Expect(err).To(MatchError(obj.ErrWronArgumentType))
+		})
+	})
+})
+
+var _ = Describe("Duration", func() {
+	minute := obj.New[obj.Duration](time.Minute)
+
+	Describe("operators", func() {
+		It("scales by integers", func() {
+			Expect(minute.OperatorAsterisk(obj.New[obj.Integer](90))).To(Equal(obj.New[obj.Duration](90 * time.Minute)))
+			Expect(minute.OperatorSlash(obj.New[obj.Integer](4))).To(Equal(obj.New[obj.Duration](15 * time.Second)))
+			Expect(minute.Inspect()).To(Equal("1m0s"))
+		})
+
+		It("fails on division by zero", func() {
+			_, err := minute.OperatorSlash(obj.New[obj.Integer](0))
+			Expect(err).To(MatchError(obj.ErrDevisionByZero))
+		})
+	})
+})
@@ -0,0 +1,20 @@
+package evaluator
+
+import "math/rand/v2"
+
+// WithRandSource sets the source of random numbers used by the standard library, a seeded source makes
+// random numbers reproducible. By default the source is seeded randomly.
+func WithRandSource(source rand.Source) Option {
+	return func(s *state) {
+		s.rand = rand.New(source) //nolint:gosec
+	}
+}
+
+// Rand returns the random number generator of the evaluator, it's not safe for concurrent use.
+func (e Evaluator) Rand() *rand.Rand {
+	if e.rand == nil {
+		e.rand = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())) //nolint:gosec
+	}
+
+	return e.rand
+}
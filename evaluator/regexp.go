@@ -0,0 +1,88 @@
+package evaluator
+
+import (
+	"container/list"
+	"fmt"
+	"regexp"
+
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+// maxCachedRegexps is the number of compiled patterns kept by the evaluator, patterns built at runtime
+// would grow the cache without a bound otherwise.
+const maxCachedRegexps = 128
+
+// regexpCache keeps the most recently used compiled patterns, the least recently used one is evicted when
+// the cache is full.
+type regexpCache struct {
+	entries map[string]*list.Element
+	order   *list.List // compiled patterns, the most recently used goes first
+}
+
+func newRegexpCache() *regexpCache {
+	return &regexpCache{entries: map[string]*list.Element{}, order: list.New()}
+}
+
+func (c *regexpCache) get(pattern string) (*regexp.Regexp, bool) {
+	entry, ok := c.entries[pattern]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(entry)
+
+	return entry.Value.(*regexp.Regexp), true //nolint:forcetypeassert
+}
+
+func (c *regexpCache) add(re *regexp.Regexp) {
+	c.entries[re.String()] = c.order.PushFront(re)
+
+	if c.order.Len() > maxCachedRegexps {
+		oldest := c.order.Remove(c.order.Back()).(*regexp.Regexp) //nolint:forcetypeassert
+		delete(c.entries, oldest.String())
+	}
+}
+
+// Regexp compiles the pattern, recently used patterns are cached by the evaluator so matching in a loop or
+// a recursive function compiles the pattern once. It's not safe for concurrent use.
+func (e Evaluator) Regexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := e.regexps.get(pattern); ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	e.regexps.add(re)
+
+	return re, nil
+}
+
+// evalMatch evaluates s =~ pattern, the pattern is a Regex or a String compiled with Regexp.
+func (e Evaluator) evalMatch(left, right obj.Object) (obj.Object, error) {
+	s, ok := left.(obj.String)
+	if !ok {
+		return obj.NIL, fmt.Errorf("%w: =~ expects String on the left, given %s", obj.ErrWronArgumentType, left.TypeName())
+	}
+
+	var re *regexp.Regexp
+
+	switch right := right.(type) {
+	case obj.Regex:
+		re = right.Value()
+	case obj.String:
+		var err error
+
+		re, err = e.Regexp(right.Value())
+		if err != nil {
+			return obj.NIL, err
+		}
+	default:
+		return obj.NIL, fmt.Errorf("%w: =~ expects String or Regex on the right, given %s",
+			obj.ErrWronArgumentType, right.TypeName())
+	}
+
+	return obj.ToBoolean(re.MatchString(s.Value())), nil
+}
@@ -0,0 +1,376 @@
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/tokens"
+)
+
+const indentation = "  "
+
+type comment struct {
+	text string
+	span tokens.Span
+}
+
+func (c comment) isLine() bool {
+	return strings.HasPrefix(c.text, "//")
+}
+
+type printer struct {
+	out      bytes.Buffer
+	depth    int
+	comments []comment
+
+	// lastLine is the source line where the last printed element ended, it is used to preserve blank lines.
+	lastLine   int
+	blockStart bool
+}
+
+// Source formats a Monkey program in the canonical style. Comments are kept: own-line comments stay
+// before the statement which follows them, comments on the line where a statement ends stay at the end
+// of that line. Comments inside of an expression are moved to the end of the statement.
+func Source(src []byte) ([]byte, error) {
+	comments, hasCode, err := scan(src)
+	if err != nil {
+		return nil, err
+	}
+
+	printer := &printer{comments: comments, blockStart: true}
+
+	if !hasCode {
+		printer.leadingComments(len(src) + 1)
+
+		return printer.out.Bytes(), nil
+	}
+
+	program, err := parser.New(lexer.New(string(src))).ParseProgram()
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %w", err)
+	}
+
+	printer.statements(program.Statements, len(src)+1)
+
+	return printer.out.Bytes(), nil
+}
+
+func scan(src []byte) ([]comment, bool, error) {
+	comments := []comment{}
+	hasCode := false
+	lex := lexer.New(string(src), lexer.WithComments())
+
+	for token, err := range lex.IterateTokens() {
+		if err != nil {
+			return nil, false, fmt.Errorf("parsing error: %w", &parser.Error{Pos: lex.Span().Start, Err: err})
+		}
+
+		if token.Type == tokens.COMMENT {
+			comments = append(comments, comment{text: token.Literal(), span: lex.Span()})
+		} else {
+			hasCode = true
+		}
+	}
+
+	return comments, hasCode, nil
+}
+
+// statements prints statements one per line with their comments,
+// comments left before the end offset are printed after the statements.
+func (p *printer) statements(stmts []ast.Statement, end int) {
+	for i, stmt := range stmts {
+		p.leadingComments(stmt.Pos().Offset)
+		p.separate(stmt.Pos().Line)
+		p.indent()
+
+		var next ast.Statement
+		if i < len(stmts)-1 {
+			next = stmts[i+1]
+		}
+
+		p.statement(stmt, next, p.depth > 0 && next == nil)
+		p.trailingComments(stmt, end)
+		p.out.WriteString("\n")
+	}
+
+	p.leadingComments(end)
+}
+
+func (p *printer) leadingComments(offset int) {
+	for len(p.comments) > 0 && p.comments[0].span.Start.Offset < offset {
+		comment := p.comments[0]
+		p.comments = p.comments[1:]
+
+		p.separate(comment.span.Start.Line)
+		p.indent()
+		p.out.WriteString(comment.text + "\n")
+		p.lastLine = comment.span.End.Line
+	}
+}
+
+// trailingComments prints comments which are inside of the statement or start on the line it ends,
+// comments after the limit offset belong to the enclosing block.
+func (p *printer) trailingComments(stmt ast.Statement, limit int) {
+	end := stmt.End()
+	afterLineComment := false
+
+	p.lastLine = end.Line
+
+	for len(p.comments) > 0 {
+		comment := p.comments[0]
+
+		inside := comment.span.Start.Offset < end.Offset
+		if comment.span.Start.Offset >= limit || !inside && comment.span.Start.Line != end.Line {
+			return
+		}
+
+		p.comments = p.comments[1:]
+
+		// Nothing can follow a line comment on the same line.
+		if afterLineComment {
+			p.out.WriteString("\n")
+			p.indent()
+		} else {
+			p.out.WriteString(" ")
+		}
+
+		p.out.WriteString(comment.text)
+
+		p.lastLine = max(p.lastLine, comment.span.End.Line)
+		afterLineComment = comment.isLine()
+	}
+}
+
+// separate keeps a single blank line between elements which were separated by blank lines in the source.
+func (p *printer) separate(line int) {
+	if !p.blockStart && line-p.lastLine > 1 {
+		p.out.WriteString("\n")
+	}
+
+	p.blockStart = false
+}
+
+func (p *printer) indent() {
+	p.out.WriteString(strings.Repeat(indentation, p.depth))
+}
+
+func (p *printer) statement(stmt, next ast.Statement, lastInBlock bool) {
+	switch stmt := stmt.(type) {
+	case *ast.LetStatement:
+		p.out.WriteString("let " + stmt.Name.V + " = ")
+		p.expression(stmt.V)
+		p.out.WriteString(";")
+	case *ast.ReturnStatement:
+		p.out.WriteString("return ")
+		p.expression(stmt.V)
+		p.out.WriteString(";")
+	case *ast.ThrowStatement:
+		p.out.WriteString("throw ")
+		p.expression(stmt.V)
+		p.out.WriteString(";")
+	case *ast.ExportStatement:
+		p.out.WriteString("export ")
+		p.statement(stmt.V, next, lastInBlock)
+	case *ast.ImportStatement:
+		p.out.WriteString(stmt.String())
+	case *ast.ExpressionStatement:
+		p.expression(stmt.V)
+
+		if !lastInBlock && needsSemicolon(stmt, next) {
+			p.out.WriteString(";")
+		}
+	}
+}
+
+// needsSemicolon reports whether the statement must be terminated explicitly. An if or try expression on its own
+// can only be followed without a semicolon by a statement which would not continue it as an operand.
+func needsSemicolon(stmt *ast.ExpressionStatement, next ast.Statement) bool {
+	switch stmt.V.(type) {
+	case *ast.IfExpression, *ast.TryExpression:
+	default:
+		return true
+	}
+
+	if next, ok := next.(*ast.ExpressionStatement); ok {
+		return startsWithOperator(next.V)
+	}
+
+	return false
+}
+
+// startsWithOperator reports whether the printed expression starts with a token which the parser would
+// treat as an infix operator applied to the previous expression.
+func startsWithOperator(expr ast.Expression) bool {
+	switch expr := expr.(type) {
+	case *ast.InfixExpression:
+		return precedenceOf(expr.V) < parser.Precedence(expr.Token.Type) || startsWithOperator(expr.V)
+	case *ast.CallExpression:
+		return precedenceOf(expr.V) < parser.CALL || startsWithOperator(expr.V)
+	case *ast.MemberExpression:
+		return precedenceOf(expr.V) < parser.CALL || startsWithOperator(expr.V)
+	case *ast.IndexExpression:
+		return precedenceOf(expr.V) < parser.CALL || startsWithOperator(expr.V)
+	case *ast.PrefixExpression:
+		return parser.Precedence(expr.Token.Type) > parser.LOWEST
+	case *ast.ArrayExpression:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *printer) expression(expr ast.Expression) { //nolint:cyclop
+	switch expr := expr.(type) {
+	case *ast.IdentifierExpression:
+		p.out.WriteString(expr.V)
+	case *ast.IntegerExpression, *ast.FloatExpression, *ast.BooleanExpression, *ast.NilExpression:
+		p.out.WriteString(expr.TokenLiteral())
+	case *ast.StringExpression:
+		p.out.WriteString(`"` + expr.V + `"`)
+	case *ast.PrefixExpression:
+		p.out.WriteString(expr.Operator)
+		p.operand(expr.V, precedenceOf(expr.V) < parser.PREFIX)
+	case *ast.InfixExpression:
+		prec := parser.Precedence(expr.Token.Type)
+
+		p.operand(expr.V, precedenceOf(expr.V) < prec)
+		p.out.WriteString(" " + expr.Operator + " ")
+		p.operand(expr.Right, precedenceOf(expr.Right) <= prec)
+	case *ast.IfExpression:
+		p.out.WriteString("if (")
+		p.expression(expr.V)
+		p.out.WriteString(") ")
+		p.block(expr.Then)
+
+		if expr.Else != nil {
+			p.out.WriteString(" else ")
+			p.block(expr.Else)
+		}
+	case *ast.TryExpression:
+		p.out.WriteString("try ")
+		p.block(expr.V)
+
+		if expr.Catch != nil {
+			p.out.WriteString(" catch (" + expr.Param.V + ") ")
+			p.block(expr.Catch)
+		}
+
+		if expr.Finally != nil {
+			p.out.WriteString(" finally ")
+			p.block(expr.Finally)
+		}
+	case *ast.FunctionExpression:
+		p.out.WriteString("fn(")
+
+		for i, arg := range expr.Arguments {
+			if i > 0 {
+				p.out.WriteString(", ")
+			}
+
+			p.out.WriteString(arg.V)
+		}
+
+		p.out.WriteString(") ")
+		p.block(expr.V)
+	case *ast.CallExpression:
+		p.operand(expr.V, precedenceOf(expr.V) < parser.CALL)
+		p.out.WriteString("(")
+
+		for i, arg := range expr.Arguments {
+			if i > 0 {
+				p.out.WriteString(", ")
+			}
+
+			p.expression(arg)
+		}
+
+		p.out.WriteString(")")
+	case *ast.MemberExpression:
+		p.operand(expr.V, precedenceOf(expr.V) < parser.CALL)
+		p.out.WriteString("." + expr.Name.V)
+	case *ast.IndexExpression:
+		p.operand(expr.V, precedenceOf(expr.V) < parser.CALL)
+		p.out.WriteString("[")
+		p.expression(expr.Index)
+		p.out.WriteString("]")
+	case *ast.ArrayExpression:
+		p.out.WriteString("[")
+
+		for i, element := range expr.V {
+			if i > 0 {
+				p.out.WriteString(", ")
+			}
+
+			p.expression(element)
+		}
+
+		p.out.WriteString("]")
+	case *ast.HashExpression:
+		p.out.WriteString("{")
+
+		for i, pair := range expr.V {
+			if i > 0 {
+				p.out.WriteString(", ")
+			}
+
+			p.expression(pair.Key)
+			p.out.WriteString(": ")
+			p.expression(pair.Value)
+		}
+
+		p.out.WriteString("}")
+	}
+}
+
+func (p *printer) operand(expr ast.Expression, parens bool) {
+	if parens {
+		p.out.WriteString("(")
+	}
+
+	p.expression(expr)
+
+	if parens {
+		p.out.WriteString(")")
+	}
+}
+
+func (p *printer) block(block *ast.BlockStatement) {
+	end := block.End().Offset - 1 // the closing brace
+
+	if len(block.V) == 0 && (len(p.comments) == 0 || p.comments[0].span.Start.Offset >= end) {
+		p.out.WriteString("{}")
+
+		return
+	}
+
+	p.out.WriteString("{\n")
+
+	p.depth++
+	p.blockStart = true
+	p.lastLine = block.Pos().Line
+
+	p.statements(block.V, end)
+
+	p.depth--
+
+	p.indent()
+	p.out.WriteString("}")
+}
+
+// precedenceOf returns the binding power of the expression as an operand, atoms bind the tightest.
+func precedenceOf(expr ast.Expression) int {
+	switch expr := expr.(type) {
+	case *ast.InfixExpression:
+		return parser.Precedence(expr.Token.Type)
+	case *ast.PrefixExpression:
+		return parser.PREFIX
+	case *ast.CallExpression, *ast.MemberExpression, *ast.IndexExpression:
+		return parser.CALL
+	default:
+		return parser.CALL + 1
+	}
+}
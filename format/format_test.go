@@ -0,0 +1,112 @@
+package format_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zhulik/monkey/format"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/parser"
+)
+
+var _ = Describe("Source", func() {
+	Context("when program is valid", func() {
+		cases := map[string]string{
+			// Spacing and parens.
+			"let   a=1+2*3;let b=(1+2)*3": "let a = 1 + 2 * 3;\nlet b = (1 + 2) * 3;\n",
+			"a - (b - c); (a - b) - c":    "a - (b - c);\na - b - c;\n",
+			"-(a + b) * !c; --a":          "-(a + b) * !c;\n--a;\n",
+			"(a < b) == (c > d)":          "a < b == c > d;\n",
+			"(a + b)(1); f(1)(2)":         "(a + b)(1);\nf(1)(2);\n",
+			`0xFF_FF; "foo"; nil; true`:   "0xFF_FF;\n\"foo\";\nnil;\ntrue;\n",
+			"1_000.50*-2.0":               "1_000.50 * -2.0;\n",
+
+			// Modules.
+			`import   "lib/m"  ;m.a . b(1)`:               "import \"lib/m\";\nm.a.b(1);\n",
+			`import {a,b} from "m"; export let c=(a+b).d`: "import { a, b } from \"m\";\nexport let c = (a + b).d;\n",
+			"if (a) { b }; (c).d":                         "if (a) {\n  b\n}\nc.d;\n",
+			"if (a) { b }; (c + d).e":                     "if (a) {\n  b\n};\n(c + d).e;\n",
+
+			// Arrays.
+			"[1,2 ,[a]][0][ 1+1 ]": "[1, 2, [a]][0][1 + 1];\n",
+			"(a.b)[0]; (a + b)[0]": "a.b[0];\n(a + b)[0];\n",
+			"if (a) { b }; [c]":    "if (a) {\n  b\n};\n[c];\n",
+			"if (a) { b }; (c)[0]": "if (a) {\n  b\n}\nc[0];\n",
+
+			// Hashes.
+			`{ "a" :1,b:{} ,}`:         "{\"a\": 1, b: {}};\n",
+			"let h = {}; if (a) { b }": "let h = {};\nif (a) {\n  b\n}\n",
+
+			// Blocks.
+			"let add = fn(a,b){a+b}":                        "let add = fn(a, b) {\n  a + b\n};\n",
+			"let f = fn(){}":                                "let f = fn() {};\n",
+			"if (a) { b; c } else { d }\nlet x = 1":         "if (a) {\n  b;\n  c\n} else {\n  d\n}\nlet x = 1;\n",
+			"if (a) { b }; (c)":                             "if (a) {\n  b\n}\nc;\n",
+			"if (a) { b }; -c":                              "if (a) {\n  b\n};\n-c;\n",
+			"if (a) { b }; (c + d) * e":                     "if (a) {\n  b\n};\n(c + d) * e;\n",
+			"fn(n) { if (n < 2) { return n; } fib(n - 1) }": "fn(n) {\n  if (n < 2) {\n    return n;\n  }\n  fib(n - 1)\n};\n",
+
+			// Errors.
+			"try{a}catch(e){throw e}finally{b}": "try {\n  a\n} catch (e) {\n  throw e;\n} finally {\n  b\n}\n",
+			"try { a } finally { }; -b":         "try {\n  a\n} finally {};\n-b;\n",
+			`throw   "boom"`:                    "throw \"boom\";\n",
+
+			// Blank lines.
+			"let a = 1;\n\n\n\nlet b = 2;\nlet c = 3;": "let a = 1;\n\nlet b = 2;\nlet c = 3;\n",
+			"fn() {\n\n  a;\n\n  b\n\n}":               "fn() {\n  a;\n\n  b\n};\n",
+
+			// Comments.
+			"// header\n\nlet a = 1; // one\n// footer": "// header\n\nlet a = 1; // one\n// footer\n",
+			"let f = fn() { a } // after":               "let f = fn() {\n  a\n}; // after\n",
+			"if (a) { b } /* b */ c":                    "if (a) {\n  b\n} /* b */\nc;\n",
+			"fn() { /* todo */ }":                       "fn() {\n  /* todo */\n};\n",
+			"fn() {\n  a\n  // last\n}":                 "fn() {\n  a\n  // last\n};\n",
+			"f(1, /* one */ 2)":                         "f(1, 2); /* one */\n",
+			"f(1, // one\n 2) /* two */":                "f(1, 2); // one\n/* two */\n",
+			"/* only */\n\n// comments":                 "/* only */\n\n// comments\n",
+			"":                                          "",
+		}
+
+		for input, output := range cases {
+			Context("when formatting "+input, func() {
+				It("returns formatted source", func() {
+					result, err := format.Source([]byte(input))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(result)).To(Equal(output))
+				})
+
+				It("is idempotent", func() {
+					result, err := format.Source([]byte(output))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(result)).To(Equal(output))
+				})
+
+				It("keeps the meaning of the program", func() {
+					if input == "" {
+						return
+					}
+
+					original, err := parser.New(lexer.New(input)).ParseProgram()
+					Expect(err).ToNot(HaveOccurred())
+
+					formatted, err := parser.New(lexer.New(output)).ParseProgram()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(formatted.String()).To(Equal(original.String()))
+				})
+			})
+		}
+	})
+
+	Context("when program is invalid", func() {
+		It("returns a positioned parsing error", func() {
+			_, err := format.Source([]byte("let a = 1;\nlet = 2"))
+			Expect(err).To(MatchError(parser.ErrInvalidToken))
+			Expect(err.Error()).To(ContainSubstring("2:5"))
+		})
+
+		It("returns a positioned lexing error", func() {
+			_, err := format.Source([]byte("let a = $"))
+			Expect(err).To(MatchError(lexer.ErrIllegalCharacter))
+			Expect(err.Error()).To(ContainSubstring("1:9"))
+		})
+	})
+})
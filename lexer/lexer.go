@@ -1,36 +1,95 @@
 package lexer
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"strings"
 
 	"github.com/zhulik/monkey/tokens"
 )
 
-var ErrIllegalCharacter = errors.New("illegal character")
+var (
+	ErrIllegalCharacter    = errors.New("illegal character")
+	ErrUnterminatedComment = errors.New("unterminated comment")
+	ErrUnterminatedString  = errors.New("unterminated string")
+	ErrMalformedNumber     = errors.New("malformed number literal")
+)
+
+type Option func(*Lexer)
+
+// WithComments makes NextToken emit comments as COMMENT trivia tokens instead of skipping them.
+func WithComments() Option {
+	return func(l *Lexer) {
+		l.emitComments = true
+	}
+}
 
 type Lexer struct {
-	input        string
-	position     int
-	readPosition int
-	ch           byte
+	reader *bufio.Reader
+	ch     byte
+	eof    bool
+	err    error
+
+	position tokens.Position // position of ch
+	span     tokens.Span
+
+	emitComments bool
 }
 
-func New(input string) *Lexer {
-	l := &Lexer{input: input}
+func New(input string, opts ...Option) *Lexer {
+	return NewReader(strings.NewReader(input), opts...)
+}
+
+// NewReader creates a lexer which reads its input incrementally from the given reader.
+func NewReader(reader io.Reader, opts ...Option) *Lexer {
+	l := &Lexer{
+		reader:   bufio.NewReader(reader),
+		position: tokens.Position{Offset: -1, Line: 1, Column: 0},
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
 	l.readChar()
 
 	return l
 }
 
-func (l *Lexer) NextToken() (tokens.Token, error) { //nolint:cyclop,funlen
-	if l.position >= len(l.input) {
+func (l *Lexer) NextToken() (tokens.Token, error) {
+	for {
+		tok, err := l.nextToken()
+
+		l.span.End = l.position
+
+		if err != nil || tok.Type != tokens.COMMENT || l.emitComments {
+			return tok, err
+		}
+	}
+}
+
+// Span returns the source span of the token, or of the offending input in case of error,
+// most recently returned by NextToken.
+func (l *Lexer) Span() tokens.Span {
+	return l.span
+}
+
+func (l *Lexer) nextToken() (tokens.Token, error) { //nolint:cyclop,funlen
+	if l.err != nil {
+		return tokens.Token{}, l.err
+	}
+
+	if l.eof {
 		return tokens.Token{}, io.EOF
 	}
 
 	l.skipWhitespaces()
 
+	l.span.Start = l.position
+
 	var tok tokens.Token
 
 	switch l.ch {
@@ -39,6 +98,10 @@ func (l *Lexer) NextToken() (tokens.Token, error) { //nolint:cyclop,funlen
 			l.readChar()
 
 			tok = tokens.New(tokens.EQ)
+		} else if l.peekChar() == '~' {
+			l.readChar()
+
+			tok = tokens.New(tokens.MATCH)
 		} else {
 			tok = tokens.New(tokens.ASSIGN)
 		}
@@ -47,7 +110,19 @@ func (l *Lexer) NextToken() (tokens.Token, error) { //nolint:cyclop,funlen
 	case '-':
 		tok = tokens.New(tokens.MINUS)
 	case '/':
-		tok = tokens.New(tokens.SLASH)
+		switch l.peekChar() {
+		case '/':
+			return tokens.New(tokens.COMMENT, l.readLineComment()), nil
+		case '*':
+			comment, err := l.readBlockComment()
+			if err != nil {
+				return tokens.Token{}, err
+			}
+
+			return tokens.New(tokens.COMMENT, comment), nil
+		default:
+			tok = tokens.New(tokens.SLASH)
+		}
 	case '*':
 		tok = tokens.New(tokens.ASTERISK)
 	case '<':
@@ -74,10 +149,18 @@ func (l *Lexer) NextToken() (tokens.Token, error) { //nolint:cyclop,funlen
 		tok = tokens.New(tokens.LBRACE)
 	case '}':
 		tok = tokens.New(tokens.RBRACE)
+	case '[':
+		tok = tokens.New(tokens.LBRACKET)
+	case ']':
+		tok = tokens.New(tokens.RBRACKET)
 	case ',':
 		tok = tokens.New(tokens.COMMA)
 	case ';':
 		tok = tokens.New(tokens.SEMICOLON)
+	case ':':
+		tok = tokens.New(tokens.COLON)
+	case '.':
+		tok = tokens.New(tokens.DOT)
 	case '!':
 		if l.peekChar() == '=' {
 			l.readChar()
@@ -98,13 +181,17 @@ func (l *Lexer) NextToken() (tokens.Token, error) { //nolint:cyclop,funlen
 	case 0:
 		defer l.readChar()
 
+		if l.err != nil {
+			return tokens.Token{}, l.err
+		}
+
 		return tokens.Token{}, io.EOF
 	default:
 		switch {
 		case isLetter(l.ch):
 			return l.identifierToken(), nil
 		case isDigit(l.ch):
-			return tokens.New(tokens.INTEGER, l.readNumber()), nil
+			return l.numberToken()
 		default:
 			defer l.readChar()
 
@@ -120,7 +207,7 @@ func (l *Lexer) NextToken() (tokens.Token, error) { //nolint:cyclop,funlen
 func (l *Lexer) Tokens() ([]tokens.Token, error) {
 	tkns := []tokens.Token{}
 
-	for token, err := l.NextToken(); !errors.Is(err, io.EOF); token, err = l.NextToken() {
+	for token, err := range l.IterateTokens() {
 		if err != nil {
 			return []tokens.Token{}, err
 		}
@@ -131,8 +218,24 @@ func (l *Lexer) Tokens() ([]tokens.Token, error) {
 	return tkns, nil
 }
 
+// IterateTokens yields tokens until the end of input. Iteration stops after the first error is yielded.
+func (l *Lexer) IterateTokens() iter.Seq2[tokens.Token, error] {
+	return func(yield func(tokens.Token, error) bool) {
+		for {
+			token, err := l.NextToken()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if !yield(token, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
 func (l *Lexer) readString() (string, error) {
-	position := l.position + 1
+	var str strings.Builder
 
 	for {
 		l.readChar()
@@ -144,11 +247,50 @@ func (l *Lexer) readString() (string, error) {
 		}
 
 		if l.ch == 0 {
-			return "", io.EOF
+			return "", fmt.Errorf("%w: \"%s", ErrUnterminatedString, str.String())
 		}
+
+		str.WriteByte(l.ch)
 	}
 
-	return l.input[position:l.position], nil
+	return str.String(), nil
+}
+
+func (l *Lexer) readLineComment() string {
+	return l.readAll(func(ch byte) bool {
+		return ch != '\n' && ch != 0
+	})
+}
+
+// readBlockComment reads a /* */ comment, nested comments must be balanced.
+func (l *Lexer) readBlockComment() (string, error) {
+	var comment strings.Builder
+
+	depth := 0
+
+	for {
+		switch {
+		case l.ch == 0:
+			return "", fmt.Errorf("%w: %s", ErrUnterminatedComment, comment.String())
+		case l.ch == '/' && l.peekChar() == '*':
+			depth++
+
+			comment.WriteByte(l.ch)
+			l.readChar()
+		case l.ch == '*' && l.peekChar() == '/':
+			depth--
+
+			comment.WriteByte(l.ch)
+			l.readChar()
+		}
+
+		comment.WriteByte(l.ch)
+		l.readChar()
+
+		if depth == 0 {
+			return comment.String(), nil
+		}
+	}
 }
 
 func (l *Lexer) identifierToken() tokens.Token {
@@ -165,44 +307,163 @@ func (l *Lexer) identifierToken() tokens.Token {
 }
 
 func (l *Lexer) readChar() {
-	if l.readPosition >= len(l.input) {
+	if l.eof {
 		l.ch = 0
+
+		return
+	}
+
+	l.position.Offset++
+
+	if l.ch == '\n' {
+		l.position.Line++
+		l.position.Column = 1
 	} else {
-		l.ch = l.input[l.readPosition]
+		l.position.Column++
 	}
 
-	l.position = l.readPosition
-	l.readPosition++
+	ch, err := l.reader.ReadByte()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			l.err = fmt.Errorf("reading input error: %w", err)
+		}
+
+		l.ch = 0
+		l.eof = true
+
+		return
+	}
+
+	l.ch = ch
 }
 
-func (l Lexer) peekChar() byte {
-	if l.readPosition >= len(l.input) {
+func (l *Lexer) peekChar() byte {
+	next, err := l.reader.Peek(1)
+	if err != nil {
 		return 0
 	}
 
-	return l.input[l.readPosition]
+	return next[0]
 }
 
 func (l *Lexer) skipWhitespaces() {
 	l.readAll(isWhitespace)
 }
 
+// readIdentifier reads letters and digits, the first character is always a letter.
 func (l *Lexer) readIdentifier() string {
-	return l.readAll(isLetter)
+	return l.readAll(func(ch byte) bool {
+		return isLetter(ch) || isDigit(ch)
+	})
+}
+
+// numberToken reads an integer, or a float if a decimal integer is followed by a dot and a digit: 1.5, 1_000.25.
+func (l *Lexer) numberToken() (tokens.Token, error) {
+	number, err := l.readNumber()
+	if err != nil {
+		return tokens.Token{}, err
+	}
+
+	if l.ch != '.' || !isDigit(l.peekChar()) || isPrefixed(number) {
+		return tokens.New(tokens.INTEGER, number), nil
+	}
+
+	l.readChar()
+
+	fraction := l.readAll(func(ch byte) bool {
+		return isDigit(ch) || isLetter(ch)
+	})
+	literal := number + "." + fraction
+
+	err = checkDigits(literal, fraction, isDigit, false)
+	if err != nil {
+		return tokens.Token{}, err
+	}
+
+	return tokens.New(tokens.FLOAT, literal), nil
+}
+
+// isPrefixed reports whether the number literal has a base prefix like 0x.
+func isPrefixed(number string) bool {
+	return len(number) > 1 && number[0] == '0' && !isDigit(number[1])
+}
+
+// readNumber reads decimal, 0x hex, 0o octal and 0b binary literals with optional _ digit separators.
+func (l *Lexer) readNumber() (string, error) {
+	literal := l.readAll(func(ch byte) bool {
+		return isDigit(ch) || isLetter(ch)
+	})
+
+	digits, isBaseDigit, prefixed := literal, isDigit, false
+
+	if len(literal) > 1 && literal[0] == '0' {
+		switch literal[1] {
+		case 'x', 'X':
+			digits, isBaseDigit, prefixed = literal[2:], isHexDigit, true
+		case 'o', 'O':
+			digits, isBaseDigit, prefixed = literal[2:], isOctalDigit, true
+		case 'b', 'B':
+			digits, isBaseDigit, prefixed = literal[2:], isBinaryDigit, true
+		default:
+			if isDigit(literal[1]) || literal[1] == '_' {
+				return "", fmt.Errorf("%w: '%s': leading zeros are not allowed, use 0o for octal", ErrMalformedNumber, literal)
+			}
+		}
+	}
+
+	err := checkDigits(literal, digits, isBaseDigit, prefixed)
+	if err != nil {
+		return "", err
+	}
+
+	return literal, nil
 }
 
-func (l *Lexer) readNumber() string {
-	return l.readAll(isDigit)
+// checkDigits validates digits of the literal, '_' is only allowed between digits, or between the base prefix
+// and the first digit of prefixed literals like 0x_FF.
+func checkDigits(literal, digits string, isBaseDigit func(byte) bool, prefixed bool) error {
+	if digits == "" || digits == "_" {
+		return fmt.Errorf("%w: '%s': no digits", ErrMalformedNumber, literal)
+	}
+
+	for i := range len(digits) {
+		switch {
+		case digits[i] == '_':
+			if i == 0 && !prefixed || i == len(digits)-1 || digits[i+1] == '_' {
+				return fmt.Errorf("%w: '%s': '_' must separate digits", ErrMalformedNumber, literal)
+			}
+		case !isBaseDigit(digits[i]):
+			return fmt.Errorf("%w: '%s': invalid digit '%c'", ErrMalformedNumber, literal, digits[i])
+		}
+	}
+
+	return nil
 }
 
 func (l *Lexer) readAll(fn func(byte) bool) string {
-	position := l.position
+	var str strings.Builder
 
 	for fn(l.ch) {
+		str.WriteByte(l.ch)
 		l.readChar()
 	}
 
-	return l.input[position:l.position]
+	return str.String()
+}
+
+// IsIdentifier reports whether the string consists of characters allowed in identifiers, keywords are not excluded.
+func IsIdentifier(str string) bool {
+	if str == "" || !isLetter(str[0]) {
+		return false
+	}
+
+	for i := range len(str) {
+		if !isLetter(str[i]) && !isDigit(str[i]) {
+			return false
+		}
+	}
+
+	return true
 }
 
 func isLetter(ch byte) bool {
@@ -216,3 +477,15 @@ func isWhitespace(ch byte) bool {
 func isDigit(ch byte) bool {
 	return '0' <= ch && ch <= '9'
 }
+
+func isHexDigit(ch byte) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+func isOctalDigit(ch byte) bool {
+	return '0' <= ch && ch <= '7'
+}
+
+func isBinaryDigit(ch byte) bool {
+	return ch == '0' || ch == '1'
+}
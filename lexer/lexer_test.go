@@ -1,10 +1,14 @@
 package lexer_test
 
 import (
+	"errors"
 	"io"
+	"strings"
+	"testing/iotest"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
 	"github.com/zhulik/monkey/lexer"
 	"github.com/zhulik/monkey/tokens"
 )
@@ -38,6 +42,108 @@ var _ = Describe("Lexer", func() {
 			})
 		})
 	})
+	Describe(".NewReader", func() {
+		Context("when input is read incrementally", func() {
+			It("returns the same tokens as for a string input", func() {
+				input := "let a = fn(x) { x * 0xF }; /* comment */ a(\"foo\");"
+
+				expected, err := lexer.New(input).Tokens()
+				Expect(err).ToNot(HaveOccurred())
+
+				tkns, err := lexer.NewReader(iotest.OneByteReader(strings.NewReader(input))).Tokens()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tkns).To(Equal(expected))
+			})
+		})
+
+		Context("when reading fails", func() {
+			readErr := errors.New("read error")
+
+			It("returns the error", func() {
+				lex := lexer.NewReader(io.MultiReader(strings.NewReader("a;"), iotest.ErrReader(readErr)))
+
+				_, err := lex.Tokens()
+				Expect(err).To(MatchError(readErr))
+			})
+		})
+	})
+
+	Describe(".Span", func() {
+		It("returns the span of the last token", func() {
+			lex := lexer.New("let a = \"b\";\n  /* c */ 10")
+			pos := func(offset, line, column int) tokens.Position {
+				return tokens.Position{Offset: offset, Line: line, Column: column}
+			}
+
+			spans := []tokens.Span{}
+
+			for _, err := range lex.IterateTokens() {
+				Expect(err).ToNot(HaveOccurred())
+
+				spans = append(spans, lex.Span())
+			}
+
+			Expect(spans).To(Equal([]tokens.Span{
+				{Start: pos(0, 1, 1), End: pos(3, 1, 4)},
+				{Start: pos(4, 1, 5), End: pos(5, 1, 6)},
+				{Start: pos(6, 1, 7), End: pos(7, 1, 8)},
+				{Start: pos(8, 1, 9), End: pos(11, 1, 12)},
+				{Start: pos(11, 1, 12), End: pos(12, 1, 13)},
+				{Start: pos(23, 2, 11), End: pos(25, 2, 13)},
+			}))
+		})
+
+		It("returns the span of the offending input on error", func() {
+			lex := lexer.New("a $")
+
+			_, err := lex.Tokens()
+			Expect(err).To(MatchError(lexer.ErrIllegalCharacter))
+			Expect(lex.Span().Start.Column).To(Equal(3))
+		})
+	})
+
+	Describe(".IterateTokens", func() {
+		It("yields all tokens", func() {
+			tkns := []tokens.Token{}
+
+			for token, err := range lexer.New("a + 1;").IterateTokens() {
+				Expect(err).ToNot(HaveOccurred())
+
+				tkns = append(tkns, token)
+			}
+
+			Expect(tkns).To(Equal([]tokens.Token{
+				tokens.New(tokens.IDENTIFIER, "a"),
+				tokens.New(tokens.PLUS),
+				tokens.New(tokens.INTEGER, "1"),
+				tokens.New(tokens.SEMICOLON),
+			}))
+		})
+
+		It("stops when the consumer breaks", func() {
+			lex := lexer.New("a + 1;")
+
+			for range lex.IterateTokens() {
+				break
+			}
+
+			token, err := lex.NextToken()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(token).To(Equal(tokens.New(tokens.PLUS)))
+		})
+
+		It("stops after an error", func() {
+			errs := []error{}
+
+			for _, err := range lexer.New("a $ b").IterateTokens() {
+				errs = append(errs, err)
+			}
+
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[1]).To(MatchError(lexer.ErrIllegalCharacter))
+		})
+	})
+
 	Describe(".Tokens", func() {
 		Context("when all tokens are correct", func() {
 			input := `=+(){},;
@@ -50,7 +156,7 @@ let add = fn(x, y) {
 
 let result = add(five, ten);
 
-!-/*5;
+!-/ *5;
 5 < 10 > 5;
 if (5 < 10) {
   return true;
@@ -192,6 +298,223 @@ if (5 < 10) {
 			})
 		})
 
+		Context("when input contains comments", func() {
+			input := `// leading comment
+let a = 1; // trailing comment
+/* block
+   comment */ a / 2;
+/* outer /* nested */ still comment */ a;
+//`
+
+			withoutComments := []tokens.Token{
+				tokens.New(tokens.LET),
+				tokens.New(tokens.IDENTIFIER, "a"),
+				tokens.New(tokens.ASSIGN),
+				tokens.New(tokens.INTEGER, "1"),
+				tokens.New(tokens.SEMICOLON),
+				tokens.New(tokens.IDENTIFIER, "a"),
+				tokens.New(tokens.SLASH),
+				tokens.New(tokens.INTEGER, "2"),
+				tokens.New(tokens.SEMICOLON),
+				tokens.New(tokens.IDENTIFIER, "a"),
+				tokens.New(tokens.SEMICOLON),
+			}
+
+			It("skips comments by default", func() {
+				tokens, err := lexer.New(input).Tokens()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tokens).To(Equal(withoutComments))
+			})
+
+			It("emits comments as trivia tokens when asked to", func() {
+				tkns, err := lexer.New(input, lexer.WithComments()).Tokens()
+				Expect(err).ToNot(HaveOccurred())
+
+				comments := lo.Filter(tkns, func(token tokens.Token, _ int) bool {
+					return token.Type == tokens.COMMENT
+				})
+
+				Expect(comments).To(Equal([]tokens.Token{
+					tokens.New(tokens.COMMENT, "// leading comment"),
+					tokens.New(tokens.COMMENT, "// trailing comment"),
+					tokens.New(tokens.COMMENT, "/* block\n   comment */"),
+					tokens.New(tokens.COMMENT, "/* outer /* nested */ still comment */"),
+					tokens.New(tokens.COMMENT, "//"),
+				}))
+			})
+		})
+
+		Context("when identifiers contain digits", func() {
+			It("reads them as part of the identifier", func() {
+				tkns, err := lexer.New(`log10 a1b2`).Tokens()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tkns).To(Equal([]tokens.Token{
+					tokens.New(tokens.IDENTIFIER, "log10"),
+					tokens.New(tokens.IDENTIFIER, "a1b2"),
+				}))
+			})
+		})
+
+		Context("when input contains a match", func() {
+			It("reads the match operator", func() {
+				tkns, err := lexer.New(`a =~ "b" = c`).Tokens()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tkns).To(Equal([]tokens.Token{
+					tokens.New(tokens.IDENTIFIER, "a"),
+					tokens.New(tokens.MATCH),
+					tokens.New(tokens.STRING, "b"),
+					tokens.New(tokens.ASSIGN),
+					tokens.New(tokens.IDENTIFIER, "c"),
+				}))
+			})
+		})
+
+		Context("when input contains hashes", func() {
+			It("reads colons", func() {
+				tkns, err := lexer.New(`{"a": 1}`).Tokens()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tkns).To(Equal([]tokens.Token{
+					tokens.New(tokens.LBRACE),
+					tokens.New(tokens.STRING, "a"),
+					tokens.New(tokens.COLON),
+					tokens.New(tokens.INTEGER, "1"),
+					tokens.New(tokens.RBRACE),
+				}))
+			})
+		})
+
+		Context("when input contains arrays", func() {
+			It("reads brackets", func() {
+				tkns, err := lexer.New(`[1][0]`).Tokens()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tkns).To(Equal([]tokens.Token{
+					tokens.New(tokens.LBRACKET),
+					tokens.New(tokens.INTEGER, "1"),
+					tokens.New(tokens.RBRACKET),
+					tokens.New(tokens.LBRACKET),
+					tokens.New(tokens.INTEGER, "0"),
+					tokens.New(tokens.RBRACKET),
+				}))
+			})
+		})
+
+		Context("when input contains modules", func() {
+			It("reads import, export and member access", func() {
+				tkns, err := lexer.New(`import { a } from "mod"; export let b = mod.c;`).Tokens()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tkns).To(Equal([]tokens.Token{
+					tokens.New(tokens.IMPORT),
+					tokens.New(tokens.LBRACE),
+					tokens.New(tokens.IDENTIFIER, "a"),
+					tokens.New(tokens.RBRACE),
+					tokens.New(tokens.FROM),
+					tokens.New(tokens.STRING, "mod"),
+					tokens.New(tokens.SEMICOLON),
+					tokens.New(tokens.EXPORT),
+					tokens.New(tokens.LET),
+					tokens.New(tokens.IDENTIFIER, "b"),
+					tokens.New(tokens.ASSIGN),
+					tokens.New(tokens.IDENTIFIER, "mod"),
+					tokens.New(tokens.DOT),
+					tokens.New(tokens.IDENTIFIER, "c"),
+					tokens.New(tokens.SEMICOLON),
+				}))
+			})
+		})
+
+		Context("when input contains error handling", func() {
+			It("reads try, catch, finally and throw", func() {
+				tkns, err := lexer.New(`try { throw e } catch (e) {} finally {}`).Tokens()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tkns).To(Equal([]tokens.Token{
+					tokens.New(tokens.TRY),
+					tokens.New(tokens.LBRACE),
+					tokens.New(tokens.THROW),
+					tokens.New(tokens.IDENTIFIER, "e"),
+					tokens.New(tokens.RBRACE),
+					tokens.New(tokens.CATCH),
+					tokens.New(tokens.LPAREN),
+					tokens.New(tokens.IDENTIFIER, "e"),
+					tokens.New(tokens.RPAREN),
+					tokens.New(tokens.LBRACE),
+					tokens.New(tokens.RBRACE),
+					tokens.New(tokens.FINALLY),
+					tokens.New(tokens.LBRACE),
+					tokens.New(tokens.RBRACE),
+				}))
+			})
+		})
+
+		Context("when a block comment is not terminated", func() {
+			lex := lexer.New("a; /* outer /* nested */ a;")
+
+			It("returns an error", func() {
+				_, err := lex.Tokens()
+				Expect(err).To(MatchError(lexer.ErrUnterminatedComment))
+			})
+		})
+
+		Context("when input contains numeric literals", func() {
+			cases := []string{"0", "42", "1_000_000", "0xFF", "0XdeadBEEF", "0o755", "0O7", "0b1010", "0b1_0", "0x_FF", "0o_7_5"}
+
+			for _, input := range cases {
+				It("reads "+input+" as an integer", func() {
+					tkns, err := lexer.New(input).Tokens()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(tkns).To(Equal([]tokens.Token{tokens.New(tokens.INTEGER, input)}))
+				})
+			}
+		})
+
+		Context("when input contains float literals", func() {
+			cases := []string{"0.5", "3.14", "1_000.000_1"}
+
+			for _, input := range cases {
+				It("reads "+input+" as a float", func() {
+					tkns, err := lexer.New(input).Tokens()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(tkns).To(Equal([]tokens.Token{tokens.New(tokens.FLOAT, input)}))
+				})
+			}
+
+			It("reads a dot followed by a non-digit as member access", func() {
+				tkns, err := lexer.New("1.a; 0x1.5").Tokens()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tkns).To(Equal([]tokens.Token{
+					tokens.New(tokens.INTEGER, "1"),
+					tokens.New(tokens.DOT),
+					tokens.New(tokens.IDENTIFIER, "a"),
+					tokens.New(tokens.SEMICOLON),
+					tokens.New(tokens.INTEGER, "0x1"),
+					tokens.New(tokens.DOT),
+					tokens.New(tokens.INTEGER, "5"),
+				}))
+			})
+		})
+
+		Context("when a numeric literal is malformed", func() {
+			cases := []string{
+				"0x", "0o", "0b_", "0x__ff", "0x_", "0xff_", "1__0", "1_", "0_9", "0_", "0xFG", "0o8", "0b102", "0755",
+				"123abc", "1.5_", "1.5e3",
+			}
+
+			for _, input := range cases {
+				It("returns an error for "+input, func() {
+					_, err := lexer.New(input).Tokens()
+					Expect(err).To(MatchError(lexer.ErrMalformedNumber))
+				})
+			}
+		})
+
+		Context("when a string is not terminated", func() {
+			lex := lexer.New(`a; "foo`)
+
+			It("returns an error", func() {
+				_, err := lex.Tokens()
+				Expect(err).To(MatchError(lexer.ErrUnterminatedString))
+			})
+		})
+
 		Context("when parsing an empty string", func() {
 			lex := lexer.New("")
 
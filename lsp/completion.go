@@ -0,0 +1,63 @@
+package lsp
+
+import (
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/scope"
+	"github.com/zhulik/monkey/tokens"
+)
+
+// completion offers keywords and names visible at the position. While the document can't be parsed,
+// names come from its last successfully parsed version.
+func (s *Server) completion(doc *document, pos Position) (any, error) {
+	items := []CompletionItem{}
+	seen := map[string]bool{}
+
+	if doc.lastInfo != nil {
+		scope := innermostScope(doc.lastInfo.Root, doc.offset(pos))
+
+		for ; scope != nil; scope = scope.Parent {
+			for i := len(scope.Bindings) - 1; i >= 0; i-- {
+				binding := scope.Bindings[i]
+				if seen[binding.Name] {
+					continue
+				}
+
+				seen[binding.Name] = true
+
+				items = append(items, completionItem(doc.lastInfo, binding))
+			}
+		}
+	}
+
+	for _, keyword := range tokens.Keywords() {
+		items = append(items, CompletionItem{Label: string(keyword), Kind: completionKeyword})
+	}
+
+	return items, nil
+}
+
+func completionItem(info *scope.Info, binding *scope.Binding) CompletionItem {
+	kind := completionVariable
+
+	switch binding.Kind {
+	case scope.Let:
+		if _, ok := binding.Let.V.(*ast.FunctionExpression); ok {
+			kind = completionFunction
+		}
+	case scope.Import:
+		kind = completionModule
+	case scope.Parameter, scope.Catch:
+	}
+
+	return CompletionItem{Label: binding.Name, Kind: kind, Detail: describe(info, binding)}
+}
+
+func innermostScope(root *scope.Scope, offset int) *scope.Scope {
+	for _, child := range root.Children {
+		if child.Node.Pos().Offset <= offset && offset < child.Node.End().Offset {
+			return innermostScope(child, offset)
+		}
+	}
+
+	return root
+}
@@ -0,0 +1,44 @@
+package lsp
+
+func (s *Server) definition(doc *document, pos Position) (any, error) {
+	ident := doc.identifierAt(pos)
+	if ident == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	binding := doc.info.BindingOf(ident)
+	if binding == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	return doc.location(binding.Ident), nil
+}
+
+func (s *Server) references(params ReferenceParams) (any, error) {
+	doc, err := s.document(params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := []Location{}
+
+	ident := doc.identifierAt(params.Position)
+	if ident == nil {
+		return locations, nil
+	}
+
+	binding := doc.info.BindingOf(ident)
+	if binding == nil {
+		return locations, nil
+	}
+
+	if params.Context.IncludeDeclaration {
+		locations = append(locations, doc.location(binding.Ident))
+	}
+
+	for _, use := range binding.Uses {
+		locations = append(locations, doc.location(use))
+	}
+
+	return locations, nil
+}
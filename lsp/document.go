@@ -0,0 +1,158 @@
+package lsp
+
+import (
+	"errors"
+	"sort"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/scope"
+	"github.com/zhulik/monkey/tokens"
+	"github.com/zhulik/monkey/vet"
+)
+
+type document struct {
+	uri   string
+	text  string
+	lines []int // offsets of line starts
+
+	// program and info are nil when the text can't be parsed, err holds the parsing error then.
+	program *ast.Program
+	info    *scope.Info
+	err     error
+
+	// lastInfo is kept from the last successful parse, it's used for completion while the user is typing.
+	lastInfo *scope.Info
+}
+
+func newDocument(uri, text string, previous *document) *document {
+	doc := &document{uri: uri, text: text, lines: []int{0}}
+
+	for i, ch := range []byte(text) {
+		if ch == '\n' {
+			doc.lines = append(doc.lines, i+1)
+		}
+	}
+
+	doc.program, doc.err = parser.New(lexer.New(text)).ParseProgram()
+	if doc.err != nil {
+		doc.program = nil
+
+		if previous != nil {
+			doc.lastInfo = previous.lastInfo
+		}
+
+		return doc
+	}
+
+	doc.info = scope.Resolve(doc.program)
+	doc.lastInfo = doc.info
+
+	return doc
+}
+
+func (d *document) diagnostics() []Diagnostic {
+	diagnostics := []Diagnostic{}
+
+	if d.err != nil {
+		pos := tokens.Position{Offset: len(d.text)}
+		message := d.err.Error()
+
+		var perr *parser.Error
+		if errors.As(d.err, &perr) {
+			pos = perr.Pos
+			message = perr.Err.Error()
+		}
+
+		start := d.position(pos.Offset)
+
+		return append(diagnostics, Diagnostic{
+			Range:    Range{Start: start, End: start},
+			Severity: severityError,
+			Source:   "monkey",
+			Message:  message,
+		})
+	}
+
+	for _, diagnostic := range vet.Run(d.program, vet.Analyzers()...) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    d.span(diagnostic.Pos, diagnostic.End),
+			Severity: severityWarning,
+			Code:     diagnostic.Analyzer,
+			Source:   "monkey vet",
+			Message:  diagnostic.Message,
+		})
+	}
+
+	return diagnostics
+}
+
+// position converts a byte offset to a protocol position, characters are counted in UTF-16 code units.
+func (d *document) position(offset int) Position {
+	offset = min(max(offset, 0), len(d.text))
+	line := sort.Search(len(d.lines), func(i int) bool { return d.lines[i] > offset }) - 1
+
+	character := 0
+	for _, r := range d.text[d.lines[line]:offset] {
+		character += utf16.RuneLen(r)
+	}
+
+	return Position{Line: line, Character: character}
+}
+
+// offset converts a protocol position to a byte offset, positions outside of the text are clamped to it.
+func (d *document) offset(pos Position) int {
+	if pos.Line >= len(d.lines) {
+		return len(d.text)
+	}
+
+	offset := d.lines[max(pos.Line, 0)]
+
+	for character := 0; character < pos.Character && offset < len(d.text) && d.text[offset] != '\n'; {
+		r, size := utf8.DecodeRuneInString(d.text[offset:])
+		character += utf16.RuneLen(r)
+		offset += size
+	}
+
+	return offset
+}
+
+func (d *document) span(start, end tokens.Position) Range {
+	return Range{Start: d.position(start.Offset), End: d.position(end.Offset)}
+}
+
+func (d *document) location(node ast.Node) Location {
+	return Location{URI: d.uri, Range: d.span(node.Pos(), node.End())}
+}
+
+func (d *document) fullRange() Range {
+	return Range{Start: Position{}, End: d.position(len(d.text))}
+}
+
+// identifierAt returns the identifier which covers the position, the position right after it counts too.
+func (d *document) identifierAt(pos Position) *ast.IdentifierExpression {
+	if d.program == nil {
+		return nil
+	}
+
+	offset := d.offset(pos)
+
+	var result *ast.IdentifierExpression
+
+	ast.Inspect(d.program, func(node ast.Node) bool {
+		if node == nil || result != nil || offset < node.Pos().Offset || offset > node.End().Offset {
+			return false
+		}
+
+		if ident, ok := node.(*ast.IdentifierExpression); ok {
+			result = ident
+		}
+
+		return true
+	})
+
+	return result
+}
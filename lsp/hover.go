@@ -0,0 +1,224 @@
+package lsp
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/scope"
+)
+
+// maxInferenceDepth limits following bindings and calls when inferring types of recursive definitions.
+const maxInferenceDepth = 16
+
+func (s *Server) hover(doc *document, pos Position) (any, error) {
+	ident := doc.identifierAt(pos)
+	if ident == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	binding := doc.info.BindingOf(ident)
+	if binding == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	return Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: "```monkey\n" + describe(doc.info, binding) + "\n```"},
+		Range:    doc.span(ident.Pos(), ident.End()),
+	}, nil
+}
+
+// describe renders the binding with its inferred type, values of literals are shown too.
+func describe(info *scope.Info, binding *scope.Binding) string {
+	if binding.Kind != scope.Let {
+		return binding.Kind.String() + " " + binding.Name
+	}
+
+	result := "let " + binding.Name
+	if binding.Exported {
+		result = "export " + result
+	}
+	inferrer := &inferrer{info: info}
+
+	switch value := binding.Let.V.(type) {
+	case *ast.FunctionExpression:
+		return result + " = " + signature(value)
+	case *ast.IntegerExpression, *ast.FloatExpression, *ast.StringExpression, *ast.BooleanExpression,
+		*ast.NilExpression:
+		return result + ": " + inferrer.infer(value) + " = " + value.String()
+	}
+
+	if typ := inferrer.infer(binding.Let.V); typ != "" {
+		result += ": " + typ
+	}
+
+	return result
+}
+
+func signature(fn *ast.FunctionExpression) string {
+	args := make([]string, len(fn.Arguments))
+	for i, arg := range fn.Arguments {
+		args[i] = arg.V
+	}
+
+	return "fn(" + strings.Join(args, ", ") + ")"
+}
+
+// inferrer infers object types of expressions, types of parameters are known inside of inferred calls.
+type inferrer struct {
+	info   *scope.Info
+	params map[*scope.Binding]string
+	depth  int
+}
+
+// infer returns the name of the object type the expression evaluates to, or an empty string if unknown.
+func (i *inferrer) infer(expr ast.Expression) string { //nolint:cyclop
+	if i.depth > maxInferenceDepth {
+		return ""
+	}
+
+	switch expr := expr.(type) {
+	case *ast.IntegerExpression:
+		return "Integer"
+	case *ast.FloatExpression:
+		return "Float"
+	case *ast.StringExpression:
+		return "String"
+	case *ast.BooleanExpression:
+		return "Boolean"
+	case *ast.NilExpression:
+		return "Nil"
+	case *ast.FunctionExpression:
+		return "Function"
+	case *ast.ArrayExpression:
+		return "Array"
+	case *ast.HashExpression:
+		return "Hash"
+	case *ast.PrefixExpression:
+		if expr.Operator == "!" {
+			return "Boolean"
+		}
+
+		return i.numeric(i.infer(expr.V))
+	case *ast.InfixExpression:
+		return i.inferInfix(expr)
+	case *ast.IfExpression:
+		then := i.inferBlock(expr.Then)
+		if expr.Else == nil || then != i.inferBlock(expr.Else) {
+			return ""
+		}
+
+		return then
+	case *ast.IdentifierExpression:
+		return i.inferIdentifier(expr)
+	case *ast.CallExpression:
+		return i.inferCall(expr)
+	default:
+		return ""
+	}
+}
+
+func (i *inferrer) inferInfix(expr *ast.InfixExpression) string {
+	switch expr.Operator {
+	case "<", ">", "<=", ">=", "==", "!=", "=~":
+		return "Boolean"
+	case "+":
+		// Other types are added to values of the same type or to durations, the result is of the left type.
+		switch left := i.infer(expr.V); left {
+		case "Integer", "Float":
+			return i.numeric(left, i.infer(expr.Right))
+		default:
+			return left
+		}
+	default:
+		return i.numeric(i.infer(expr.V), i.infer(expr.Right))
+	}
+}
+
+// numeric infers the result of arithmetic on operands of the types, integers are promoted to floats. The result
+// is unknown unless all operands are numbers, other types may define the operators too.
+func (i *inferrer) numeric(types ...string) string {
+	for _, typ := range types {
+		if typ != "Integer" && typ != "Float" {
+			return ""
+		}
+	}
+
+	if slices.Contains(types, "Float") {
+		return "Float"
+	}
+
+	return "Integer"
+}
+
+func (i *inferrer) inferIdentifier(expr *ast.IdentifierExpression) string {
+	binding := i.info.BindingOf(expr)
+	if binding == nil {
+		return ""
+	}
+
+	switch binding.Kind {
+	case scope.Parameter:
+		return i.params[binding]
+	case scope.Catch:
+		return "Error"
+	case scope.Import:
+		// Types of exported names are not known without loading the module.
+		if binding.Import.Name == binding.Ident {
+			return "Module"
+		}
+
+		return ""
+	case scope.Let:
+	}
+
+	if binding.Let == nil {
+		return ""
+	}
+
+	return i.nested(i.params).infer(binding.Let.V)
+}
+
+func (i *inferrer) inferCall(expr *ast.CallExpression) string {
+	fn, ok := expr.V.(*ast.FunctionExpression)
+
+	if ident, isIdent := expr.V.(*ast.IdentifierExpression); isIdent {
+		if binding := i.info.BindingOf(ident); binding != nil && binding.Kind == scope.Let {
+			fn, ok = binding.Let.V.(*ast.FunctionExpression)
+		}
+	}
+
+	if !ok {
+		return ""
+	}
+
+	params := map[*scope.Binding]string{}
+
+	for n, arg := range fn.Arguments {
+		if n < len(expr.Arguments) {
+			params[i.info.BindingOf(arg)] = i.infer(expr.Arguments[n])
+		}
+	}
+
+	return i.nested(params).inferBlock(fn.V)
+}
+
+// inferBlock infers the type of the last statement of the block which is its value.
+func (i *inferrer) inferBlock(block *ast.BlockStatement) string {
+	if len(block.V) == 0 {
+		return "Nil"
+	}
+
+	switch stmt := block.V[len(block.V)-1].(type) {
+	case *ast.ExpressionStatement:
+		return i.nested(i.params).infer(stmt.V)
+	case *ast.ReturnStatement:
+		return i.nested(i.params).infer(stmt.V)
+	default:
+		return ""
+	}
+}
+
+func (i *inferrer) nested(params map[*scope.Binding]string) *inferrer {
+	return &inferrer{info: i.info, params: params, depth: i.depth + 1}
+}
@@ -0,0 +1,239 @@
+// Package lsp implements a Language Server Protocol server for Monkey.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/zhulik/monkey/format"
+	"github.com/zhulik/monkey/transport"
+)
+
+var ErrUnknownDocument = errors.New("unknown document")
+
+type handler func(s *Server, params json.RawMessage) (any, error)
+
+var handlers = map[string]handler{ //nolint:gochecknoglobals
+	"initialize":              (*Server).initialize,
+	"shutdown":                (*Server).shutdown,
+	"textDocument/definition": withPosition((*Server).definition),
+	"textDocument/references": withParams((*Server).references),
+	"textDocument/hover":      withPosition((*Server).hover),
+	"textDocument/completion": withPosition((*Server).completion),
+	"textDocument/formatting": withParams((*Server).formatting),
+}
+
+var notificationHandlers = map[string]func(s *Server, params json.RawMessage) error{ //nolint:gochecknoglobals
+	"textDocument/didOpen":   notificationWithParams((*Server).didOpen),
+	"textDocument/didChange": notificationWithParams((*Server).didChange),
+	"textDocument/didClose":  notificationWithParams((*Server).didClose),
+}
+
+// Server serves a single client, requests are handled sequentially.
+type Server struct {
+	in        *bufio.Reader
+	out       io.Writer
+	documents map[string]*document
+}
+
+func NewServer(in io.Reader, out io.Writer) *Server {
+	return &Server{
+		in:        bufio.NewReader(in),
+		out:       out,
+		documents: map[string]*document{},
+	}
+}
+
+// Serve handles messages until the client sends the exit notification or closes the input.
+func (s *Server) Serve() error {
+	for {
+		body, err := transport.ReadMessage(s.in)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		var msg message
+
+		err = json.Unmarshal(body, &msg)
+		if err != nil {
+			err = s.reply(nil, nil, ResponseError{Code: codeParseError, Message: err.Error()})
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		err = s.handle(msg)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg message) error {
+	if msg.ID == nil {
+		handler, ok := notificationHandlers[msg.Method]
+		if !ok {
+			return nil // unknown notifications are ignored
+		}
+
+		return handler(s, msg.Params)
+	}
+
+	handler, ok := handlers[msg.Method]
+	if !ok {
+		return s.reply(msg.ID, nil, ResponseError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method})
+	}
+
+	result, err := handler(s, msg.Params)
+
+	return s.reply(msg.ID, result, err)
+}
+
+func (s *Server) reply(id *json.RawMessage, result any, err error) error {
+	if err != nil {
+		var rerr ResponseError
+		if !errors.As(err, &rerr) {
+			rerr = ResponseError{Code: codeInvalidParams, Message: err.Error()}
+		}
+
+		return s.send(errorResponse{JSONRPC: "2.0", ID: id, Error: rerr})
+	}
+
+	return s.send(response{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *Server) notify(method string, params any) error {
+	return s.send(notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (s *Server) send(msg any) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("encoding message error: %w", err)
+	}
+
+	return transport.WriteMessage(s.out, body)
+}
+
+func (s *Server) document(uri string) (*document, error) {
+	doc, ok := s.documents[uri]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDocument, uri)
+	}
+
+	return doc, nil
+}
+
+func (s *Server) initialize(_ json.RawMessage) (any, error) {
+	return InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync:           syncFull,
+			DefinitionProvider:         true,
+			ReferencesProvider:         true,
+			HoverProvider:              true,
+			CompletionProvider:         map[string]any{},
+			DocumentFormattingProvider: true,
+		},
+		ServerInfo: ServerInfo{Name: "monkey"},
+	}, nil
+}
+
+func (s *Server) shutdown(_ json.RawMessage) (any, error) {
+	return nil, nil //nolint:nilnil
+}
+
+func (s *Server) didOpen(params DidOpenTextDocumentParams) error {
+	return s.update(params.TextDocument.URI, params.TextDocument.Text)
+}
+
+func (s *Server) didChange(params DidChangeTextDocumentParams) error {
+	if len(params.ContentChanges) == 0 {
+		return nil
+	}
+
+	return s.update(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+}
+
+func (s *Server) didClose(params DidCloseTextDocumentParams) error {
+	delete(s.documents, params.TextDocument.URI)
+
+	return s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         params.TextDocument.URI,
+		Diagnostics: []Diagnostic{},
+	})
+}
+
+func (s *Server) update(uri, text string) error {
+	doc := newDocument(uri, text, s.documents[uri])
+	s.documents[uri] = doc
+
+	return s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: doc.diagnostics(),
+	})
+}
+
+func (s *Server) formatting(params DocumentFormattingParams) (any, error) {
+	doc, err := s.document(params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := format.Source([]byte(doc.text))
+	if err != nil {
+		return nil, nil //nolint:nilerr,nilnil // the document has errors, they are reported as diagnostics
+	}
+
+	return []TextEdit{{Range: doc.fullRange(), NewText: string(result)}}, nil
+}
+
+func withParams[T any](fn func(s *Server, params T) (any, error)) handler {
+	return func(s *Server, raw json.RawMessage) (any, error) {
+		var params T
+
+		err := json.Unmarshal(raw, &params)
+		if err != nil {
+			return nil, fmt.Errorf("decoding params error: %w", err)
+		}
+
+		return fn(s, params)
+	}
+}
+
+// withPosition resolves the document of position params.
+func withPosition(fn func(s *Server, doc *document, pos Position) (any, error)) handler {
+	return withParams(func(s *Server, params TextDocumentPositionParams) (any, error) {
+		doc, err := s.document(params.TextDocument.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		return fn(s, doc, params.Position)
+	})
+}
+
+func notificationWithParams[T any](fn func(s *Server, params T) error) func(s *Server, params json.RawMessage) error {
+	return func(s *Server, raw json.RawMessage) error {
+		var params T
+
+		err := json.Unmarshal(raw, &params)
+		if err != nil {
+			return nil //nolint:nilerr // malformed notifications can't be answered
+		}
+
+		return fn(s, params)
+	}
+}
@@ -0,0 +1,379 @@
+package lsp_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zhulik/monkey/lsp"
+)
+
+const uri = "file:///test.mk"
+
+// client is a scripted LSP client talking to an in-process server.
+type client struct {
+	in            io.WriteCloser
+	out           *bufio.Reader
+	id            int
+	notifications []map[string]any
+	done          chan error
+}
+
+func newClient() *client {
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+
+	c := &client{in: clientOut, out: bufio.NewReader(clientIn), done: make(chan error, 1)}
+
+	go func() {
+		c.done <- lsp.NewServer(serverIn, serverOut).Serve()
+		serverOut.Close()
+	}()
+
+	return c
+}
+
+func (c *client) send(msg map[string]any) {
+	msg["jsonrpc"] = "2.0"
+
+	body, err := json.Marshal(msg)
+	Expect(err).ToNot(HaveOccurred())
+
+	_, err = fmt.Fprintf(c.in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	Expect(err).ToNot(HaveOccurred())
+}
+
+func (c *client) read() map[string]any {
+	header, err := textproto.NewReader(c.out).ReadMIMEHeader()
+	Expect(err).ToNot(HaveOccurred())
+
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	Expect(err).ToNot(HaveOccurred())
+
+	body := make([]byte, length)
+	_, err = io.ReadFull(c.out, body)
+	Expect(err).ToNot(HaveOccurred())
+
+	var msg map[string]any
+	Expect(json.Unmarshal(body, &msg)).To(Succeed())
+
+	return msg
+}
+
+// request sends a request and returns its response, notifications received meanwhile are queued.
+func (c *client) request(method string, params any) map[string]any {
+	c.id++
+	c.send(map[string]any{"id": c.id, "method": method, "params": params})
+
+	for {
+		msg := c.read()
+		if _, ok := msg["id"]; ok {
+			Expect(msg["id"]).To(BeEquivalentTo(c.id))
+
+			return msg
+		}
+
+		c.notifications = append(c.notifications, msg)
+	}
+}
+
+func (c *client) result(method string, params any) any {
+	response := c.request(method, params)
+	Expect(response).ToNot(HaveKey("error"))
+
+	return response["result"]
+}
+
+func (c *client) notify(method string, params any) {
+	c.send(map[string]any{"method": method, "params": params})
+}
+
+// diagnostics waits for the next published diagnostics.
+func (c *client) diagnostics() []any {
+	if len(c.notifications) == 0 {
+		c.notifications = append(c.notifications, c.read())
+	}
+
+	msg := c.notifications[0]
+	c.notifications = c.notifications[1:]
+
+	Expect(msg["method"]).To(Equal("textDocument/publishDiagnostics"))
+
+	return msg["params"].(map[string]any)["diagnostics"].([]any)
+}
+
+func (c *client) open(text string) []any {
+	c.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "languageId": "monkey", "version": 1, "text": text},
+	})
+
+	return c.diagnostics()
+}
+
+func (c *client) close() {
+	Expect(c.result("shutdown", nil)).To(BeNil())
+	c.notify("exit", nil)
+	Eventually(c.done).Should(Receive(BeNil()))
+}
+
+func at(line, character int) map[string]any {
+	return map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     map[string]any{"line": line, "character": character},
+	}
+}
+
+func rng(startLine, startCharacter, endLine, endCharacter int) map[string]any {
+	return map[string]any{
+		"start": map[string]any{"line": float64(startLine), "character": float64(startCharacter)},
+		"end":   map[string]any{"line": float64(endLine), "character": float64(endCharacter)},
+	}
+}
+
+func location(startLine, startCharacter, endLine, endCharacter int) map[string]any {
+	return map[string]any{"uri": uri, "range": rng(startLine, startCharacter, endLine, endCharacter)}
+}
+
+var _ = Describe("Server", func() {
+	var c *client
+
+	source := "let add = fn(a, b) {\n  a + b\n};\nlet x = add(1, 2);\nlet s = \"é\" + x;\ns\n"
+
+	BeforeEach(func() {
+		c = newClient()
+
+		result := c.result("initialize", map[string]any{"capabilities": map[string]any{}})
+		Expect(result).To(HaveKeyWithValue("serverInfo", HaveKeyWithValue("name", "monkey")))
+		Expect(result).To(HaveKeyWithValue("capabilities", HaveKeyWithValue("definitionProvider", true)))
+
+		c.notify("initialized", map[string]any{})
+	})
+
+	AfterEach(func() {
+		c.close()
+	})
+
+	Describe("diagnostics", func() {
+		It("publishes parsing errors", func() {
+			Expect(c.open("let a = 1;\nlet = 2")).To(ConsistOf(And(
+				HaveKeyWithValue("range", rng(1, 4, 1, 4)),
+				HaveKeyWithValue("severity", BeEquivalentTo(1)),
+				HaveKeyWithValue("message", ContainSubstring("invalid token")),
+			)))
+		})
+
+		It("publishes vet warnings", func() {
+			Expect(c.open("let a = 1")).To(ConsistOf(And(
+				HaveKeyWithValue("range", rng(0, 4, 0, 5)),
+				HaveKeyWithValue("severity", BeEquivalentTo(2)),
+				HaveKeyWithValue("code", "unused"),
+			)))
+		})
+
+		It("publishes diagnostics on change and clears them on close", func() {
+			Expect(c.open("a")).To(BeEmpty())
+
+			c.notify("textDocument/didChange", map[string]any{
+				"textDocument":   map[string]any{"uri": uri, "version": 2},
+				"contentChanges": []any{map[string]any{"text": "let"}},
+			})
+			Expect(c.diagnostics()).To(HaveLen(1))
+
+			c.notify("textDocument/didClose", map[string]any{"textDocument": map[string]any{"uri": uri}})
+			Expect(c.diagnostics()).To(BeEmpty())
+		})
+	})
+
+	Describe("navigation", func() {
+		BeforeEach(func() {
+			Expect(c.open(source)).To(BeEmpty())
+		})
+
+		It("finds definition of a let binding", func() {
+			Expect(c.result("textDocument/definition", at(3, 9))).To(Equal(location(0, 4, 0, 7)))
+		})
+
+		It("finds definition of a parameter", func() {
+			Expect(c.result("textDocument/definition", at(1, 6))).To(Equal(location(0, 16, 0, 17)))
+		})
+
+		It("counts characters in UTF-16 code units", func() {
+			Expect(c.result("textDocument/definition", at(4, 15))).To(Equal(location(3, 4, 3, 5)))
+		})
+
+		It("returns null when there is nothing to go to", func() {
+			Expect(c.result("textDocument/definition", at(0, 0))).To(BeNil())
+		})
+
+		It("finds references", func() {
+			params := at(0, 5)
+			params["context"] = map[string]any{"includeDeclaration": true}
+
+			Expect(c.result("textDocument/references", params)).To(Equal([]any{
+				location(0, 4, 0, 7),
+				location(3, 8, 3, 11),
+			}))
+
+			params["context"] = map[string]any{"includeDeclaration": false}
+
+			Expect(c.result("textDocument/references", params)).To(Equal([]any{location(3, 8, 3, 11)}))
+		})
+	})
+
+	Describe("hover", func() {
+		BeforeEach(func() {
+			Expect(c.open(source)).To(BeEmpty())
+		})
+
+		cases := map[string]struct {
+			line, character int
+		}{
+			"let add = fn(a, b)": {0, 5},
+			"parameter a":        {1, 2},
+			"let x: Integer":     {3, 5},
+			"let s: String":      {5, 0},
+		}
+
+		for expected, pos := range cases {
+			It("shows "+expected, func() {
+				result := c.result("textDocument/hover", at(pos.line, pos.character))
+				Expect(result).To(HaveKeyWithValue("contents", HaveKeyWithValue("value", "```monkey\n"+expected+"\n```")))
+			})
+		}
+
+		It("clamps negative positions to the start of the document", func() {
+			Expect(c.result("textDocument/hover", at(-1, 5))).
+				To(HaveKeyWithValue("contents", HaveKeyWithValue("value", "```monkey\nlet add = fn(a, b)\n```")))
+			Expect(c.request("textDocument/hover", at(-1, -5))).To(HaveKeyWithValue("result", BeNil()))
+		})
+
+		It("shows values of literals", func() {
+			c.notify("textDocument/didChange", map[string]any{
+				"textDocument":   map[string]any{"uri": uri, "version": 2},
+				"contentChanges": []any{map[string]any{"text": "let a = 5; a"}},
+			})
+			c.diagnostics()
+
+			result := c.result("textDocument/hover", at(0, 11))
+			Expect(result).To(HaveKeyWithValue("contents", HaveKeyWithValue("value", "```monkey\nlet a: Integer = 5\n```")))
+			Expect(result).To(HaveKeyWithValue("range", rng(0, 11, 0, 12)))
+		})
+
+		It("infers floats", func() {
+			c.notify("textDocument/didChange", map[string]any{
+				"textDocument":   map[string]any{"uri": uri, "version": 2},
+				"contentChanges": []any{map[string]any{"text": "let a = 2 * -1.5; a"}},
+			})
+			c.diagnostics()
+
+			Expect(c.result("textDocument/hover", at(0, 18))).
+				To(HaveKeyWithValue("contents", HaveKeyWithValue("value", "```monkey\nlet a: Float\n```")))
+		})
+
+		It("does not infer arithmetic on unknown operands", func() {
+			text := "import \"time\";\nfn(x) { let n = -x; n };\nlet d = time.SECOND * 2; let f = 1 + 2.5; d; f"
+			c.notify("textDocument/didChange", map[string]any{
+				"textDocument":   map[string]any{"uri": uri, "version": 2},
+				"contentChanges": []any{map[string]any{"text": text}},
+			})
+			c.diagnostics()
+
+			Expect(c.result("textDocument/hover", at(1, 20))).
+				To(HaveKeyWithValue("contents", HaveKeyWithValue("value", "```monkey\nlet n\n```")))
+			Expect(c.result("textDocument/hover", at(2, 42))).
+				To(HaveKeyWithValue("contents", HaveKeyWithValue("value", "```monkey\nlet d\n```")))
+			Expect(c.result("textDocument/hover", at(2, 45))).
+				To(HaveKeyWithValue("contents", HaveKeyWithValue("value", "```monkey\nlet f: Float\n```")))
+		})
+
+		It("shows imports and exports", func() {
+			c.notify("textDocument/didChange", map[string]any{
+				"textDocument":   map[string]any{"uri": uri, "version": 2},
+				"contentChanges": []any{map[string]any{"text": "import \"lib/m\";\nexport let a = m.b;"}},
+			})
+			c.diagnostics()
+
+			Expect(c.result("textDocument/hover", at(1, 15))).
+				To(HaveKeyWithValue("contents", HaveKeyWithValue("value", "```monkey\nimport m\n```")))
+			Expect(c.result("textDocument/hover", at(1, 11))).
+				To(HaveKeyWithValue("contents", HaveKeyWithValue("value", "```monkey\nexport let a\n```")))
+		})
+
+		It("infers types of imported names", func() {
+			text := "import \"lib/m\"; import { b } from \"n\";\nlet x = m; let y = b; x; y"
+			c.notify("textDocument/didChange", map[string]any{
+				"textDocument":   map[string]any{"uri": uri, "version": 2},
+				"contentChanges": []any{map[string]any{"text": text}},
+			})
+			c.diagnostics()
+
+			Expect(c.result("textDocument/hover", at(1, 22))).
+				To(HaveKeyWithValue("contents", HaveKeyWithValue("value", "```monkey\nlet x: Module\n```")))
+			Expect(c.result("textDocument/hover", at(1, 25))).
+				To(HaveKeyWithValue("contents", HaveKeyWithValue("value", "```monkey\nlet y\n```")))
+		})
+	})
+
+	Describe("completion", func() {
+		labels := func(result any) []string {
+			labels := []string{}
+			for _, item := range result.([]any) {
+				labels = append(labels, item.(map[string]any)["label"].(string))
+			}
+
+			return labels
+		}
+
+		It("offers visible names and keywords", func() {
+			c.open(source)
+
+			result := labels(c.result("textDocument/completion", at(1, 4)))
+			Expect(result).To(ContainElements("a", "b", "add", "x", "s", "let", "fn", "return"))
+
+			result = labels(c.result("textDocument/completion", at(5, 0)))
+			Expect(result).To(ContainElements("add", "x", "s", "let"))
+			Expect(result).ToNot(ContainElements("a", "b"))
+		})
+
+		It("uses the last parsed version of an invalid document", func() {
+			c.open("let foo = 1; foo")
+
+			c.notify("textDocument/didChange", map[string]any{
+				"textDocument":   map[string]any{"uri": uri, "version": 2},
+				"contentChanges": []any{map[string]any{"text": "let foo = 1; foo; let bar = "}},
+			})
+			Expect(c.diagnostics()).To(HaveLen(1))
+
+			Expect(labels(c.result("textDocument/completion", at(0, 28)))).To(ContainElement("foo"))
+		})
+	})
+
+	Describe("formatting", func() {
+		It("replaces the document with formatted source", func() {
+			c.open("let a=fn(x){x}\n\n\n\na(1)")
+
+			Expect(c.result("textDocument/formatting", map[string]any{
+				"textDocument": map[string]any{"uri": uri},
+				"options":      map[string]any{"tabSize": 2, "insertSpaces": true},
+			})).To(Equal([]any{map[string]any{
+				"range":   rng(0, 0, 4, 4),
+				"newText": "let a = fn(x) {\n  x\n};\n\na(1);\n",
+			}}))
+		})
+	})
+
+	Describe("errors", func() {
+		It("responds to unknown methods", func() {
+			Expect(c.request("foo/bar", nil)).To(HaveKeyWithValue("error", HaveKeyWithValue("code", BeEquivalentTo(-32601))))
+		})
+
+		It("responds to requests for unknown documents", func() {
+			Expect(c.request("textDocument/hover", at(0, 0))).To(HaveKeyWithValue("error", HaveKeyWithValue("message", ContainSubstring("unknown document"))))
+		})
+	})
+})
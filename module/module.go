@@ -0,0 +1,159 @@
+// Package module loads modules imported by Monkey programs from a filesystem.
+package module
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/parser"
+)
+
+// Extension is added to import paths without an extension.
+const Extension = ".mk"
+
+var (
+	ErrNotFound    = errors.New("module not found")
+	ErrOutsideRoot = errors.New("module is outside of the filesystem")
+	ErrImportCycle = errors.New("import cycle")
+)
+
+type Option func(*Loader)
+
+// ParseHook is called with every module file parsed by the loader, before the module is evaluated.
+type ParseHook func(file, source string, program *ast.Program)
+
+// WithSearchPath adds directories of the filesystem where modules are looked up when they are not found
+// next to the importing file.
+func WithSearchPath(dirs ...string) Option {
+	return func(l *Loader) {
+		l.searchPath = append(l.searchPath, dirs...)
+	}
+}
+
+// WithParseHook adds the hook, it allows recording coverage of imported modules for instance.
+func WithParseHook(hook ParseHook) Option {
+	return func(l *Loader) {
+		l.parseHooks = append(l.parseHooks, hook)
+	}
+}
+
+// Loader resolves import paths to files of the filesystem and evaluates each module once, later imports get
+// the cached module. A loader is bound to the evaluation it is used in: modules keep the evaluator which
+// imported them first, so it's not safe for concurrent use.
+type Loader struct {
+	fsys       fs.FS
+	searchPath []string
+	parseHooks []ParseHook
+	cache      map[string]obj.Module
+	loading    []string // files being evaluated, the importing program goes first
+}
+
+// New creates a loader which reads modules from the filesystem only, file names of evaluators must be paths
+// in this filesystem.
+func New(fsys fs.FS, opts ...Option) *Loader {
+	loader := &Loader{fsys: fsys, cache: map[string]obj.Module{}}
+
+	for _, opt := range opts {
+		opt(loader)
+	}
+
+	return loader
+}
+
+// Option returns the evaluator option which enables imports.
+func (l *Loader) Option() evaluator.Option {
+	return evaluator.WithImporter(l)
+}
+
+// Import resolves the path relative to the file of the evaluator: paths starting with ./ or ../ are looked up
+// next to the file only, other paths are looked up next to the file, in the search path and then in the root
+// of the filesystem.
+func (l *Loader) Import(e evaluator.Evaluator, importPath string) (obj.Module, error) {
+	file, err := l.resolve(e.File(), importPath)
+	if err != nil {
+		return obj.Module{}, err
+	}
+
+	if module, ok := l.cache[file]; ok {
+		return module, nil
+	}
+
+	if len(l.loading) == 0 && e.File() != "" {
+		l.loading = []string{path.Clean(e.File())}
+		defer func() { l.loading = nil }()
+	}
+
+	if slices.Contains(l.loading, file) {
+		return obj.Module{}, fmt.Errorf("%w: %s", ErrImportCycle, strings.Join(append(l.loading, file), " -> "))
+	}
+
+	src, err := fs.ReadFile(l.fsys, file)
+	if err != nil {
+		return obj.Module{}, fmt.Errorf("reading module error: %w", err)
+	}
+
+	program, err := parser.New(lexer.New(string(src))).ParseProgram()
+	if err != nil {
+		return obj.Module{}, fmt.Errorf("%s:%w", file, err)
+	}
+
+	for _, hook := range l.parseHooks {
+		hook(file, string(src), program)
+	}
+
+	l.loading = append(l.loading, file)
+	defer func() { l.loading = l.loading[:len(l.loading)-1] }()
+
+	module, err := e.EvalModule(file, program)
+	if err != nil {
+		return obj.Module{}, err //nolint:wrapcheck
+	}
+
+	l.cache[file] = module
+
+	return module, nil
+}
+
+func (l *Loader) resolve(from, importPath string) (string, error) {
+	if path.Ext(importPath) == "" {
+		importPath += Extension
+	}
+
+	candidates := []string{path.Join(path.Dir(from), importPath)}
+
+	if strings.HasPrefix(importPath, "./") || strings.HasPrefix(importPath, "../") {
+		// Parent directories can't lead out of the filesystem, the host decides what is imported.
+		if !fs.ValidPath(candidates[0]) {
+			return "", fmt.Errorf("%w: %s", ErrOutsideRoot, importPath)
+		}
+	} else {
+		for _, dir := range l.searchPath {
+			candidates = append(candidates, path.Join(dir, importPath))
+		}
+
+		candidates = append(candidates, path.Clean(importPath))
+	}
+
+	candidates = slices.Compact(candidates)
+
+	for _, candidate := range candidates {
+		if !fs.ValidPath(candidate) {
+			continue
+		}
+
+		info, err := fs.Stat(l.fsys, candidate)
+		if err == nil && info.Mode().IsRegular() {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s, tried: %s", ErrNotFound, importPath, strings.Join(candidates, ", "))
+}
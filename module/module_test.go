@@ -0,0 +1,204 @@
+package module_test
+
+import (
+	"io/fs"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/module"
+	"github.com/zhulik/monkey/parser"
+)
+
+func file(source string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(source)}
+}
+
+func run(fsys fs.FS, main string, opts ...module.Option) (obj.Object, error) {
+	program := lo.Must(parser.New(lexer.New(string(lo.Must(fs.ReadFile(fsys, main))))).ParseProgram())
+
+	return evaluator.New(evaluator.WithFile(main), module.New(fsys, opts...).Option()).Eval(program)
+}
+
+var _ = Describe("Loader", func() {
+	var fsys fstest.MapFS
+
+	BeforeEach(func() {
+		fsys = fstest.MapFS{
+			"lib/math.mk": file(`import { twice } from "./util"; export let double = fn(x) { twice(fn(y) { y + x }, 0) }; let secret = 1`),
+			"lib/util.mk": file(`export let twice = fn(f, x) { f(f(x)) }`),
+		}
+	})
+
+	Context("when the whole module is imported", func() {
+		It("binds the module to the last element of the path", func() {
+			fsys["main.mk"] = file(`import "lib/math"; math.double(21)`)
+
+			result, err := run(fsys, "main.mk")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Inspect()).To(Equal("42"))
+		})
+
+		It("hides names which are not exported", func() {
+			fsys["main.mk"] = file(`import "lib/math"; math.secret`)
+
+			_, err := run(fsys, "main.mk")
+			Expect(err).To(MatchError(obj.ErrNotExported))
+			Expect(err).To(MatchError("name is not exported: math.secret"))
+		})
+	})
+
+	It("calls parse hooks with every parsed module", func() {
+		fsys["main.mk"] = file(`import "lib/math"; import "lib/util"; math.double(1)`)
+
+		parsed := []string{}
+		hook := module.WithParseHook(func(file, _ string, program *ast.Program) {
+			parsed = append(parsed, file+": "+program.String())
+		})
+
+		_, err := run(fsys, "main.mk", hook)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed).To(Equal([]string{
+			"lib/math.mk: " + lo.Must(parser.New(lexer.New(string(fsys["lib/math.mk"].Data))).ParseProgram()).String(),
+			"lib/util.mk: export let twice = fn(f, x) { f(f(x)) };",
+		}))
+	})
+
+	Context("when names are imported", func() {
+		It("binds the names", func() {
+			fsys["main.mk"] = file(`import { double } from "lib/math.mk"; double(2)`)
+
+			result, err := run(fsys, "main.mk")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Inspect()).To(Equal("4"))
+		})
+	})
+
+	Context("when the module is not next to the importing file", func() {
+		BeforeEach(func() {
+			fsys["app/main.mk"] = file(`import { twice } from "util"; twice(fn(x) { x * 3 }, 1)`)
+		})
+
+		It("finds the module in the search path", func() {
+			result, err := run(fsys, "app/main.mk", module.WithSearchPath("lib"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Inspect()).To(Equal("9"))
+		})
+
+		It("finds the module in the root", func() {
+			fsys["util.mk"] = fsys["lib/util.mk"]
+
+			result, err := run(fsys, "app/main.mk")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Inspect()).To(Equal("9"))
+		})
+
+		It("doesn't look further for explicitly relative paths", func() {
+			fsys["app/main.mk"] = file(`import "./util"`)
+
+			_, err := run(fsys, "app/main.mk", module.WithSearchPath("lib"))
+			Expect(err).To(MatchError("module not found: ./util.mk, tried: app/util.mk"))
+		})
+
+		It("resolves parent directories", func() {
+			fsys["app/main.mk"] = file(`import "../lib/util"; util.twice(fn(x) { x + 1 }, 0)`)
+
+			result, err := run(fsys, "app/main.mk")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Inspect()).To(Equal("2"))
+		})
+
+		It("doesn't escape the filesystem", func() {
+			fsys["main.mk"] = file(`import "../lib/util"`)
+
+			_, err := run(fsys, "main.mk")
+			Expect(err).To(MatchError(module.ErrOutsideRoot))
+			Expect(err).To(MatchError("module is outside of the filesystem: ../lib/util.mk"))
+		})
+	})
+
+	Context("when the module is imported twice", func() {
+		It("evaluates it once", func() {
+			loader := module.New(fsys)
+			eval := evaluator.New(evaluator.WithFile("main.mk"), loader.Option())
+
+			first, err := loader.Import(eval, "lib/util")
+			Expect(err).ToNot(HaveOccurred())
+
+			second, err := loader.Import(eval, "./lib/util.mk")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(second.Env).To(BeIdenticalTo(first.Env))
+		})
+	})
+
+	Context("when modules import each other", func() {
+		It("returns an error with the chain", func() {
+			fsys["a.mk"] = file(`import "b"`)
+			fsys["b.mk"] = file(`import "lib/c"`)
+			fsys["lib/c.mk"] = file(`import "../a"`)
+			fsys["main.mk"] = file(`import "a"`)
+
+			_, err := run(fsys, "main.mk")
+			Expect(err).To(MatchError("import cycle: main.mk -> a.mk -> b.mk -> lib/c.mk -> a.mk"))
+		})
+
+		It("detects imports of the main program", func() {
+			fsys["main.mk"] = file(`import "a"`)
+			fsys["a.mk"] = file(`import "main"`)
+
+			_, err := run(fsys, "main.mk")
+			Expect(err).To(MatchError(module.ErrImportCycle))
+		})
+	})
+
+	Context("when the module is invalid", func() {
+		It("returns a positioned error", func() {
+			fsys["bad.mk"] = file("let = 1")
+			fsys["main.mk"] = file(`import "bad"`)
+
+			_, err := run(fsys, "main.mk")
+			Expect(err).To(MatchError(parser.ErrInvalidToken))
+			Expect(err.Error()).To(HavePrefix("bad.mk:1:5: "))
+		})
+	})
+
+	Context("when the module doesn't exist", func() {
+		It("returns an error", func() {
+			fsys["main.mk"] = file(`import "missing"`)
+
+			_, err := run(fsys, "main.mk")
+			Expect(err).To(MatchError(module.ErrNotFound))
+		})
+	})
+})
+
+var _ = Describe("Overlay", func() {
+	upper := fstest.MapFS{"a.mk": file("upper"), "dir/b.mk": file("b")}
+	lower := fstest.MapFS{"a.mk": file("lower"), "dir/c.mk": file("c"), "d.mk": file("d")}
+
+	overlay := module.Overlay(upper, lower)
+
+	It("opens files from the first filesystem which has them", func() {
+		Expect(fs.ReadFile(overlay, "a.mk")).To(Equal([]byte("upper")))
+		Expect(fs.ReadFile(overlay, "d.mk")).To(Equal([]byte("d")))
+
+		_, err := fs.ReadFile(overlay, "e.mk")
+		Expect(err).To(MatchError(fs.ErrNotExist))
+	})
+
+	It("merges directories", func() {
+		entries, err := fs.ReadDir(overlay, "dir")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lo.Map(entries, func(entry fs.DirEntry, _ int) string { return entry.Name() })).
+			To(Equal([]string{"b.mk", "c.mk"}))
+	})
+
+	It("is a valid filesystem", func() {
+		Expect(fstest.TestFS(overlay, "a.mk", "d.mk", "dir/b.mk", "dir/c.mk")).To(Succeed())
+	})
+})
@@ -0,0 +1,110 @@
+package module
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"slices"
+	"strings"
+)
+
+type overlay []fs.FS
+
+// Overlay combines filesystems, a file is opened from the first filesystem which has it. Directories list
+// entries of all filesystems.
+func Overlay(layers ...fs.FS) fs.FS {
+	return overlay(layers)
+}
+
+func (o overlay) Open(name string) (fs.File, error) {
+	for _, layer := range o {
+		file, err := layer.Open(name)
+		if err == nil {
+			return o.wrap(name, file)
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err //nolint:wrapcheck
+		}
+	}
+
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+func (o overlay) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries := []fs.DirEntry{}
+	found := false
+
+	for _, layer := range o {
+		layerEntries, err := fs.ReadDir(layer, name)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return nil, err //nolint:wrapcheck
+		}
+
+		found = true
+
+		for _, entry := range layerEntries {
+			if !slices.ContainsFunc(entries, func(e fs.DirEntry) bool { return e.Name() == entry.Name() }) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	return entries, nil
+}
+
+// wrap makes a directory list entries of all filesystems.
+func (o overlay) wrap(name string, file fs.File) (fs.File, error) {
+	info, err := file.Stat()
+	if err != nil || !info.IsDir() {
+		return file, err //nolint:wrapcheck
+	}
+
+	entries, err := o.ReadDir(name)
+	if err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	return &dir{File: file, entries: entries}, nil
+}
+
+// dir is a directory of the first filesystem which has it, with entries of all of them.
+type dir struct {
+	fs.File
+
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *dir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+
+	if n <= 0 {
+		d.offset = len(d.entries)
+
+		return rest, nil
+	}
+
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+
+	n = min(n, len(rest))
+	d.offset += n
+
+	return rest[:n], nil
+}
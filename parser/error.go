@@ -0,0 +1,25 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/zhulik/monkey/tokens"
+)
+
+// Error is a parsing error with the position of the offending input.
+type Error struct {
+	Pos tokens.Position
+	Err error
+}
+
+func newError(pos tokens.Position, err error) *Error {
+	return &Error{Pos: pos, Err: err}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path"
 	"strconv"
+	"strings"
 
 	"github.com/zhulik/monkey/ast"
 	"github.com/zhulik/monkey/lexer"
@@ -14,10 +16,15 @@ import (
 var (
 	ErrInvalidToken        = errors.New("invalid token")
 	ErrNoPrefixParserFound = errors.New("no prefix parse function found for")
+	ErrUnexpectedEOF       = errors.New("unexpected end of input")
+	ErrNotTopLevel         = errors.New("statement is only allowed at the top level")
+	ErrInvalidModuleName   = errors.New("module name is not a valid identifier")
+	ErrMissingCatch        = errors.New("try must be followed by catch or finally")
 
 	precedences = map[tokens.TokenType]int{ //nolint:gochecknoglobals
 		tokens.EQ:       EQUALS,
 		tokens.NEQ:      EQUALS,
+		tokens.MATCH:    EQUALS,
 		tokens.LT:       LESSGREATER,
 		tokens.GT:       LESSGREATER,
 		tokens.LTE:      LESSGREATER,
@@ -27,6 +34,8 @@ var (
 		tokens.SLASH:    PRODUCT,
 		tokens.ASTERISK: PRODUCT,
 		tokens.LPAREN:   CALL,
+		tokens.DOT:      CALL,
+		tokens.LBRACKET: INDEX,
 	}
 )
 
@@ -45,12 +54,15 @@ const (
 	PRODUCT
 	PREFIX
 	CALL
+	INDEX
 )
 
 type Parser struct {
 	lexer        *lexer.Lexer
 	currentToken tokens.Token
 	peekToken    tokens.Token
+	currentSpan  tokens.Span
+	peekSpan     tokens.Span
 
 	prefixParseFns map[tokens.TokenType]prefixParseFn
 	infixParseFns  map[tokens.TokenType]infixParseFn
@@ -62,15 +74,19 @@ func New(l *lexer.Lexer) *Parser {
 	parser.prefixParseFns = map[tokens.TokenType]prefixParseFn{
 		tokens.IDENTIFIER: parser.parseIdentifierExpression,
 		tokens.INTEGER:    parser.parseIntegerExpression,
+		tokens.FLOAT:      parser.parseFloatExpression,
 		tokens.BANG:       parser.parsePrefixExpression,
 		tokens.MINUS:      parser.parsePrefixExpression,
 		tokens.TRUE:       parser.parseBooleanExpression,
 		tokens.FALSE:      parser.parseBooleanExpression,
 		tokens.LPAREN:     parser.parseGroupedExpression,
 		tokens.IF:         parser.parseIfExpression,
+		tokens.TRY:        parser.parseTryExpression,
 		tokens.FUNCTION:   parser.parseFunctionExpression,
 		tokens.NIL:        parser.parseNilExpression,
 		tokens.STRING:     parser.parseStringExpression,
+		tokens.LBRACKET:   parser.parseArrayExpression,
+		tokens.LBRACE:     parser.parseHashExpression,
 	}
 	parser.infixParseFns = map[tokens.TokenType]infixParseFn{
 		tokens.PLUS:     parser.parseInfixExpression,
@@ -79,11 +95,14 @@ func New(l *lexer.Lexer) *Parser {
 		tokens.ASTERISK: parser.parseInfixExpression,
 		tokens.EQ:       parser.parseInfixExpression,
 		tokens.NEQ:      parser.parseInfixExpression,
+		tokens.MATCH:    parser.parseInfixExpression,
 		tokens.LT:       parser.parseInfixExpression,
 		tokens.GT:       parser.parseInfixExpression,
 		tokens.LTE:      parser.parseInfixExpression,
 		tokens.GTE:      parser.parseInfixExpression,
 		tokens.LPAREN:   parser.parseCallExpression,
+		tokens.DOT:      parser.parseMemberExpression,
+		tokens.LBRACKET: parser.parseIndexExpression,
 	}
 
 	return parser
@@ -92,17 +111,17 @@ func New(l *lexer.Lexer) *Parser {
 func (p *Parser) ParseProgram() (*ast.Program, error) {
 	program := &ast.Program{}
 
-	nErr := p.nextToken()
+	nErr := p.nextTokenIgnoreEOF()
 	if nErr != nil {
 		return nil, nErr
 	}
 
-	for err := p.nextTokenIgnoreEOF(); !errors.Is(err, io.EOF); err = p.nextToken() {
+	for err := p.nextTokenIgnoreEOF(); p.currentToken.Type != tokens.EOF; err = p.nextTokenIgnoreEOF() {
 		if err != nil {
 			return nil, err
 		}
 
-		stmt, sErr := p.parseStatement()
+		stmt, sErr := p.parseTopLevelStatement()
 		if sErr != nil {
 			return nil, sErr
 		}
@@ -113,33 +132,156 @@ func (p *Parser) ParseProgram() (*ast.Program, error) {
 	return program, nil
 }
 
+// parseTopLevelStatement parses statements allowed at the top level only, imports and exports, or any other statement.
+func (p *Parser) parseTopLevelStatement() (ast.Statement, error) {
+	switch p.currentToken.Type { //nolint:exhaustive
+	case tokens.IMPORT:
+		return p.parseImportStatement()
+	case tokens.EXPORT:
+		return p.parseExportStatement()
+	default:
+		return p.parseStatement()
+	}
+}
+
 func (p *Parser) parseStatement() (ast.Statement, error) {
 	switch p.currentToken.Type { //nolint:exhaustive
 	case tokens.LET:
 		return p.parseLetStatement()
 	case tokens.RETURN:
 		return p.parseReturnStatement()
+	case tokens.THROW:
+		return p.parseThrowStatement()
+	case tokens.IMPORT, tokens.EXPORT:
+		return nil, newError(p.currentSpan.Start, fmt.Errorf("%w: %s", ErrNotTopLevel, p.currentToken.Type))
 	default:
 		return p.parseExpressionStatement()
 	}
 }
 
+// parseImportStatement parses `import "path"`, which binds the module to the last element of the path,
+// and `import { a, b } from "path"`.
+func (p *Parser) parseImportStatement() (*ast.ImportStatement, error) {
+	stmt := newNode[ast.ImportStatement, *ast.StringExpression](p)
+
+	if p.peekToken.Type == tokens.LBRACE {
+		var err error
+
+		stmt.Names, err = p.parseImportNames()
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.expectPeek(tokens.FROM)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := p.expectPeek(tokens.STRING)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt.V = newNode[ast.StringExpression](p, p.currentToken.Literal())
+
+	if stmt.Names == nil {
+		name := moduleName(stmt.V.V)
+		if tokens.TypeFromLiteral(name) != tokens.IDENTIFIER || !lexer.IsIdentifier(name) {
+			return nil, newError(p.currentSpan.Start, fmt.Errorf("%w: %s", ErrInvalidModuleName, name))
+		}
+
+		stmt.Name = newNode[ast.IdentifierExpression](p, name)
+	}
+
+	if p.peekToken.Type == tokens.SEMICOLON {
+		err = p.nextTokenIgnoreEOF()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stmt.Span.End = p.currentSpan.End
+
+	return stmt, nil
+}
+
+// parseImportNames parses a non-empty list of comma separated identifiers in braces.
+func (p *Parser) parseImportNames() ([]*ast.IdentifierExpression, error) {
+	names := []*ast.IdentifierExpression{}
+
+	err := p.expectPeek(tokens.LBRACE)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		err = p.expectPeek(tokens.IDENTIFIER)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, newNode[ast.IdentifierExpression](p, p.currentToken.Literal()))
+
+		if p.peekToken.Type != tokens.COMMA {
+			break
+		}
+
+		err = p.nextTokenIgnoreEOF()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = p.expectPeek(tokens.RBRACE)
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (p *Parser) parseExportStatement() (*ast.ExportStatement, error) {
+	stmt := newNode[ast.ExportStatement, *ast.LetStatement](p)
+
+	err := p.expectPeek(tokens.LET)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt.V, err = p.parseLetStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	stmt.Span.End = p.currentSpan.End
+
+	return stmt, nil
+}
+
+// moduleName returns the last element of the module path without the extension.
+func moduleName(modulePath string) string {
+	base := path.Base(modulePath)
+
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
 func (p *Parser) parseLetStatement() (*ast.LetStatement, error) {
-	stmt := ast.NewValueNode[ast.LetStatement, ast.Expression](p.currentToken)
+	stmt := newNode[ast.LetStatement, ast.Expression](p)
 
 	err := p.expectPeek(tokens.IDENTIFIER)
 	if err != nil {
 		return nil, err
 	}
 
-	stmt.Name = ast.NewValueNode[ast.IdentifierExpression](p.currentToken, p.currentToken.Literal())
+	stmt.Name = newNode[ast.IdentifierExpression](p, p.currentToken.Literal())
 
 	err = p.expectPeek(tokens.ASSIGN)
 	if err != nil {
 		return nil, err
 	}
 
-	err = p.nextToken()
+	err = p.nextTokenIgnoreEOF()
 	if err != nil {
 		return nil, err
 	}
@@ -156,13 +298,40 @@ func (p *Parser) parseLetStatement() (*ast.LetStatement, error) {
 		}
 	}
 
+	stmt.Span.End = p.currentSpan.End
+
 	return stmt, nil
 }
 
 func (p *Parser) parseReturnStatement() (*ast.ReturnStatement, error) {
-	stmt := ast.NewValueNode[ast.ReturnStatement, ast.Expression](p.currentToken)
+	stmt := newNode[ast.ReturnStatement, ast.Expression](p)
+
+	err := p.nextTokenIgnoreEOF()
+	if err != nil {
+		return nil, err
+	}
+
+	stmt.V, err = p.parseExpression(LOWEST)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peekToken.Type == tokens.SEMICOLON {
+		err = p.nextTokenIgnoreEOF()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stmt.Span.End = p.currentSpan.End
+
+	return stmt, nil
+}
+
+func (p *Parser) parseThrowStatement() (*ast.ThrowStatement, error) {
+	stmt := newNode[ast.ThrowStatement, ast.Expression](p)
 
-	err := p.nextToken()
+	err := p.nextTokenIgnoreEOF()
 	if err != nil {
 		return nil, err
 	}
@@ -179,11 +348,13 @@ func (p *Parser) parseReturnStatement() (*ast.ReturnStatement, error) {
 		}
 	}
 
+	stmt.Span.End = p.currentSpan.End
+
 	return stmt, nil
 }
 
 func (p *Parser) parseExpressionStatement() (*ast.ExpressionStatement, error) {
-	stmt := ast.NewValueNode[ast.ExpressionStatement, ast.Expression](p.currentToken)
+	stmt := newNode[ast.ExpressionStatement, ast.Expression](p)
 
 	var err error
 
@@ -199,13 +370,19 @@ func (p *Parser) parseExpressionStatement() (*ast.ExpressionStatement, error) {
 		}
 	}
 
+	stmt.Span.End = p.currentSpan.End
+
 	return stmt, nil
 }
 
 func (p *Parser) parseExpression(prec int) (ast.Expression, error) {
+	if p.currentToken.Type == tokens.EOF {
+		return nil, newError(p.currentSpan.Start, ErrUnexpectedEOF)
+	}
+
 	prefix := p.prefixParseFns[p.currentToken.Type]
 	if prefix == nil {
-		return nil, fmt.Errorf("%w %s", ErrNoPrefixParserFound, p.currentToken.Type)
+		return nil, newError(p.currentSpan.Start, fmt.Errorf("%w %s", ErrNoPrefixParserFound, p.currentToken.Type))
 	}
 
 	leftExpr, err := prefix()
@@ -228,10 +405,6 @@ func (p *Parser) parseExpression(prec int) (ast.Expression, error) {
 
 		leftExpr, lErr = infix(leftExpr)
 		if lErr != nil {
-			if errors.Is(lErr, io.EOF) {
-				return leftExpr, nil
-			}
-
 			return nil, lErr
 		}
 	}
@@ -240,24 +413,38 @@ func (p *Parser) parseExpression(prec int) (ast.Expression, error) {
 }
 
 func (p *Parser) parseIdentifierExpression() (ast.Expression, error) {
-	return ast.NewValueNode[ast.IdentifierExpression](p.currentToken, p.currentToken.Literal()), nil
+	return newNode[ast.IdentifierExpression](p, p.currentToken.Literal()), nil
 }
 
 func (p *Parser) parseIntegerExpression() (ast.Expression, error) {
-	expr := ast.NewValueNode[ast.IntegerExpression, int64](p.currentToken)
+	expr := newNode[ast.IntegerExpression, int64](p)
 
 	var err error
 
-	expr.V, err = strconv.ParseInt(p.currentToken.Literal(), 10, 64)
+	// Base 0 handles 0x, 0o and 0b prefixes and _ separators, the lexer has already validated the literal.
+	expr.V, err = strconv.ParseInt(p.currentToken.Literal(), 0, 64)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing integer expression: %w", err)
+		return nil, newError(p.currentSpan.Start, fmt.Errorf("error parsing integer expression: %w", err))
+	}
+
+	return expr, nil
+}
+
+func (p *Parser) parseFloatExpression() (ast.Expression, error) {
+	expr := newNode[ast.FloatExpression, float64](p)
+
+	var err error
+
+	expr.V, err = strconv.ParseFloat(strings.ReplaceAll(p.currentToken.Literal(), "_", ""), 64)
+	if err != nil {
+		return nil, newError(p.currentSpan.Start, fmt.Errorf("error parsing float expression: %w", err))
 	}
 
 	return expr, nil
 }
 
 func (p *Parser) parsePrefixExpression() (ast.Expression, error) {
-	expr := ast.NewValueNode[ast.PrefixExpression, ast.Expression](p.currentToken)
+	expr := newNode[ast.PrefixExpression, ast.Expression](p)
 	expr.Operator = p.currentToken.Literal()
 
 	err := p.nextTokenIgnoreEOF()
@@ -270,15 +457,17 @@ func (p *Parser) parsePrefixExpression() (ast.Expression, error) {
 		return nil, err
 	}
 
+	expr.Span.End = p.currentSpan.End
+
 	return expr, nil
 }
 
 func (p *Parser) parseBooleanExpression() (ast.Expression, error) {
-	return ast.NewValueNode[ast.BooleanExpression](p.currentToken, p.currentToken.Type == tokens.TRUE), nil
+	return newNode[ast.BooleanExpression](p, p.currentToken.Type == tokens.TRUE), nil
 }
 
 func (p *Parser) parseGroupedExpression() (ast.Expression, error) {
-	err := p.nextToken()
+	err := p.nextTokenIgnoreEOF()
 	if err != nil {
 		return nil, err
 	}
@@ -297,14 +486,14 @@ func (p *Parser) parseGroupedExpression() (ast.Expression, error) {
 }
 
 func (p *Parser) parseIfExpression() (ast.Expression, error) {
-	expr := ast.NewValueNode[ast.IfExpression, ast.Expression](p.currentToken)
+	expr := newNode[ast.IfExpression, ast.Expression](p)
 
 	err := p.expectPeek(tokens.LPAREN)
 	if err != nil {
 		return nil, err
 	}
 
-	err = p.nextToken()
+	err = p.nextTokenIgnoreEOF()
 	if err != nil {
 		return nil, err
 	}
@@ -325,7 +514,7 @@ func (p *Parser) parseIfExpression() (ast.Expression, error) {
 	}
 
 	if p.peekToken.Type == tokens.ELSE {
-		err = p.nextToken()
+		err = p.nextTokenIgnoreEOF()
 		if err != nil {
 			return nil, err
 		}
@@ -336,17 +525,94 @@ func (p *Parser) parseIfExpression() (ast.Expression, error) {
 		}
 	}
 
+	expr.Span.End = p.currentSpan.End
+
 	return expr, nil
 }
 
-func (p *Parser) parseBlockStatement() (*ast.BlockStatement, error) {
-	block := ast.NewValueNode[ast.BlockStatement, []ast.Statement](p.currentToken)
+// parseTryExpression parses `try { } catch (e) { } finally { }`, either catch or finally may be omitted.
+func (p *Parser) parseTryExpression() (ast.Expression, error) {
+	expr := newNode[ast.TryExpression, *ast.BlockStatement](p)
+
+	var err error
+
+	expr.V, err = p.parseBlockStatement()
+	if err != nil {
+		return nil, err
+	}
 
+	if p.peekToken.Type == tokens.CATCH {
+		expr.Param, expr.Catch, err = p.parseCatch()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peekToken.Type == tokens.FINALLY {
+		err = p.nextTokenIgnoreEOF()
+		if err != nil {
+			return nil, err
+		}
+
+		expr.Finally, err = p.parseBlockStatement()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if expr.Catch == nil && expr.Finally == nil {
+		if p.peekToken.Type == tokens.EOF {
+			return nil, newError(p.peekSpan.Start, fmt.Errorf("%w. Expected: catch or finally", ErrUnexpectedEOF))
+		}
+
+		return nil, newError(p.peekSpan.Start, ErrMissingCatch)
+	}
+
+	expr.Span.End = p.currentSpan.End
+
+	return expr, nil
+}
+
+// parseCatch parses `catch (e) { }`.
+func (p *Parser) parseCatch() (*ast.IdentifierExpression, *ast.BlockStatement, error) {
+	err := p.nextTokenIgnoreEOF()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = p.expectPeek(tokens.LPAREN)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = p.expectPeek(tokens.IDENTIFIER)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	param := newNode[ast.IdentifierExpression](p, p.currentToken.Literal())
+
+	err = p.expectPeek(tokens.RPAREN)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block, err := p.parseBlockStatement()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return param, block, nil
+}
+
+func (p *Parser) parseBlockStatement() (*ast.BlockStatement, error) {
 	err := p.expectPeek(tokens.LBRACE)
 	if err != nil {
 		return nil, err
 	}
 
+	block := newNode[ast.BlockStatement, []ast.Statement](p)
+
 	err = p.nextTokenIgnoreEOF()
 	if err != nil {
 		return nil, err
@@ -366,12 +632,15 @@ func (p *Parser) parseBlockStatement() (*ast.BlockStatement, error) {
 		}
 	}
 
+	block.Span.End = p.currentSpan.End
+
 	return block, nil
 }
 
 func (p *Parser) parseInfixExpression(left ast.Expression) (ast.Expression, error) {
-	expr := ast.NewValueNode[ast.InfixExpression](p.currentToken, left)
+	expr := newNode[ast.InfixExpression](p, left)
 	expr.Operator = p.currentToken.Literal()
+	expr.Span.Start = left.Pos()
 
 	precedence := precedence(p.currentToken)
 
@@ -385,26 +654,139 @@ func (p *Parser) parseInfixExpression(left ast.Expression) (ast.Expression, erro
 		return nil, err
 	}
 
+	expr.Span.End = p.currentSpan.End
+
 	return expr, nil
 }
 
 func (p *Parser) parseCallExpression(function ast.Expression) (ast.Expression, error) {
-	expr := ast.NewValueNode[ast.CallExpression](p.currentToken, function)
+	expr := newNode[ast.CallExpression](p, function)
+	expr.Span.Start = function.Pos()
+
+	var err error
+
+	expr.Arguments, err = p.parseExpressionList(tokens.RPAREN)
+	if err != nil {
+		return nil, err
+	}
+
+	expr.Span.End = p.currentSpan.End
+
+	return expr, nil
+}
+
+func (p *Parser) parseMemberExpression(object ast.Expression) (ast.Expression, error) {
+	expr := newNode[ast.MemberExpression](p, object)
+	expr.Span.Start = object.Pos()
+
+	err := p.expectPeek(tokens.IDENTIFIER)
+	if err != nil {
+		return nil, err
+	}
+
+	expr.Name = newNode[ast.IdentifierExpression](p, p.currentToken.Literal())
+	expr.Span.End = p.currentSpan.End
+
+	return expr, nil
+}
+
+func (p *Parser) parseIndexExpression(left ast.Expression) (ast.Expression, error) {
+	expr := newNode[ast.IndexExpression](p, left)
+	expr.Span.Start = left.Pos()
+
+	err := p.nextTokenIgnoreEOF()
+	if err != nil {
+		return nil, err
+	}
+
+	expr.Index, err = p.parseExpression(LOWEST)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.expectPeek(tokens.RBRACKET)
+	if err != nil {
+		return nil, err
+	}
+
+	expr.Span.End = p.currentSpan.End
+
+	return expr, nil
+}
+
+func (p *Parser) parseArrayExpression() (ast.Expression, error) {
+	expr := newNode[ast.ArrayExpression, []ast.Expression](p)
 
 	var err error
 
-	expr.Arguments, err = p.parseCallArguments()
+	expr.V, err = p.parseExpressionList(tokens.RBRACKET)
+	if err != nil {
+		return nil, err
+	}
+
+	expr.Span.End = p.currentSpan.End
+
+	return expr, nil
+}
+
+// parseHashExpression parses comma separated key: value pairs in braces.
+func (p *Parser) parseHashExpression() (ast.Expression, error) {
+	expr := newNode[ast.HashExpression, []ast.HashPair](p)
+	expr.V = []ast.HashPair{}
+
+	for p.peekToken.Type != tokens.RBRACE {
+		err := p.nextTokenIgnoreEOF()
+		if err != nil {
+			return nil, err
+		}
+
+		var pair ast.HashPair
+
+		pair.Key, err = p.parseExpression(LOWEST)
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.expectPeek(tokens.COLON)
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.nextTokenIgnoreEOF()
+		if err != nil {
+			return nil, err
+		}
+
+		pair.Value, err = p.parseExpression(LOWEST)
+		if err != nil {
+			return nil, err
+		}
+
+		expr.V = append(expr.V, pair)
+
+		if p.peekToken.Type != tokens.RBRACE {
+			err = p.expectPeek(tokens.COMMA)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err := p.expectPeek(tokens.RBRACE)
 	if err != nil {
 		return nil, err
 	}
 
+	expr.Span.End = p.currentSpan.End
+
 	return expr, nil
 }
 
-func (p *Parser) parseCallArguments() ([]ast.Expression, error) {
+// parseExpressionList parses comma separated expressions up to the end token.
+func (p *Parser) parseExpressionList(end tokens.TokenType) ([]ast.Expression, error) {
 	args := []ast.Expression{}
 
-	for err := p.nextToken(); p.currentToken.Type != tokens.RPAREN; err = p.nextToken() {
+	for err := p.nextToken(); p.currentToken.Type != end; err = p.nextToken() {
 		if err != nil {
 			return nil, err
 		}
@@ -423,7 +805,7 @@ func (p *Parser) parseCallArguments() ([]ast.Expression, error) {
 }
 
 func (p *Parser) parseFunctionExpression() (ast.Expression, error) {
-	expr := ast.NewValueNode[ast.FunctionExpression, *ast.BlockStatement](p.currentToken)
+	expr := newNode[ast.FunctionExpression, *ast.BlockStatement](p)
 
 	err := p.expectPeek(tokens.LPAREN)
 	if err != nil {
@@ -440,15 +822,17 @@ func (p *Parser) parseFunctionExpression() (ast.Expression, error) {
 		return nil, err
 	}
 
+	expr.Span.End = p.currentSpan.End
+
 	return expr, nil
 }
 
 func (p *Parser) parseNilExpression() (ast.Expression, error) {
-	return ast.NewValueNode[ast.NilExpression, any](p.currentToken), nil
+	return newNode[ast.NilExpression, any](p), nil
 }
 
 func (p *Parser) parseStringExpression() (ast.Expression, error) {
-	return ast.NewValueNode[ast.StringExpression](p.currentToken, p.currentToken.Literal()), nil
+	return newNode[ast.StringExpression](p, p.currentToken.Literal()), nil
 }
 
 func (p *Parser) parseFunctionArguments() ([]*ast.IdentifierExpression, error) {
@@ -460,7 +844,7 @@ func (p *Parser) parseFunctionArguments() ([]*ast.IdentifierExpression, error) {
 		}
 
 		if p.currentToken.Type != tokens.COMMA {
-			args = append(args, ast.NewValueNode[ast.IdentifierExpression](p.currentToken, p.currentToken.Literal()))
+			args = append(args, newNode[ast.IdentifierExpression](p, p.currentToken.Literal()))
 		}
 	}
 
@@ -469,7 +853,7 @@ func (p *Parser) parseFunctionArguments() ([]*ast.IdentifierExpression, error) {
 
 func (p *Parser) expectPeek(tokenType tokens.TokenType) error {
 	if p.peekToken.Type == tokenType {
-		err := p.nextToken()
+		err := p.nextTokenIgnoreEOF()
 		if err != nil {
 			return err
 		}
@@ -477,20 +861,29 @@ func (p *Parser) expectPeek(tokenType tokens.TokenType) error {
 		return nil
 	}
 
-	return fmt.Errorf("%w. Expected: %s, found: %s(%s)",
+	if p.peekToken.Type == tokens.EOF {
+		return newError(p.peekSpan.Start, fmt.Errorf("%w. Expected: %s", ErrUnexpectedEOF, tokenType))
+	}
+
+	return newError(p.peekSpan.Start, fmt.Errorf("%w. Expected: %s, found: %s(%s)",
 		ErrInvalidToken,
 		tokenType,
 		p.peekToken.Type,
 		p.peekToken.Literal(),
-	)
+	))
 }
 
 func (p *Parser) nextToken() error {
 	p.currentToken = p.peekToken
+	p.currentSpan = p.peekSpan
 
 	peekToken, err := p.lexer.NextToken()
+	p.peekSpan = p.lexer.Span()
+
 	if err != nil {
-		return fmt.Errorf("reading next token error: %w", err)
+		p.peekToken = tokens.New(tokens.EOF)
+
+		return newError(p.peekSpan.Start, fmt.Errorf("reading next token error: %w", err))
 	}
 
 	p.peekToken = peekToken
@@ -511,9 +904,26 @@ func ignoreError(err, errToIgnore error) error {
 }
 
 func precedence(token tokens.Token) int {
-	if p, ok := precedences[token.Type]; ok {
+	return Precedence(token.Type)
+}
+
+// Precedence returns the binding power of the token when used as an infix operator.
+func Precedence(tokenType tokens.TokenType) int {
+	if p, ok := precedences[tokenType]; ok {
 		return p
 	}
 
 	return LOWEST
 }
+
+// newNode creates a node for the current token and sets its span to the token's one,
+// parse functions extend the span once the whole node is consumed.
+func newNode[T any, V any, PT interface {
+	ast.TokenValuer[V]
+	*T
+}](p *Parser, values ...V) *T {
+	node := ast.NewValueNode[T, V, PT](p.currentToken, values...)
+	PT(node).SetSpan(p.currentSpan)
+
+	return node
+}
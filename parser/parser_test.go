@@ -1,8 +1,12 @@
 package parser_test
 
 import (
+	"errors"
+	"io"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/zhulik/monkey/ast"
 	"github.com/zhulik/monkey/lexer"
 	"github.com/zhulik/monkey/parser"
 )
@@ -13,11 +17,13 @@ var _ = Describe("Parser", func() {
 			cases := map[string]string{
 				// Let statements.
 				"let a = 5;":       "let a = 5;",
+				"let a = 5":        "let a = 5;",
 				"let foo = bar;":   "let foo = bar;",
 				"let a = bar + 5;": "let a = (bar + 5);",
 
 				// // return statements.
 				"return 5;":       "return 5;",
+				"return 5":        "return 5;",
 				"return bar;":     "return bar;",
 				"return bar + 5;": "return (bar + 5);",
 
@@ -26,6 +32,7 @@ var _ = Describe("Parser", func() {
 				// Basic expressions.
 				"foobar":         "foobar",
 				"12345":          "12345",
+				"1_000 + 0xFF":   "(1_000 + 0xFF)",
 				"!5":             "(!5)",
 				"-15":            "(-15)",
 				"5 + 5":          "(5 + 5)",
@@ -41,6 +48,7 @@ var _ = Describe("Parser", func() {
 				"true":           "true",
 				"false":          "false",
 				"2 > 3 == false": "((2 > 3) == false)",
+				`a =~ "b" + c`:   `(a =~ ("b" + c))`,
 
 				// Expressions and operator precedence.
 				"-a * b":                     "((-a) * b)",
@@ -51,6 +59,7 @@ var _ = Describe("Parser", func() {
 				"a * b / c":                  "((a * b) / c)",
 				"a + b * c + d / e - f":      "(((a + (b * c)) + (d / e)) - f)",
 				"3 + 4; -5 * 5":              "(3 + 4)((-5) * 5)",
+				"let a = 1; a":               "let a = 1;a",
 				"5 > 4 == 3 < 4":             "((5 > 4) == (3 < 4))",
 				"5 > 4 != 3 > 4":             "((5 > 4) != (3 > 4))",
 				"3 + 4 * 5 == 3 * 1 + 4 * 5": "((3 + (4 * 5)) == ((3 * 1) + (4 * 5)))",
@@ -80,6 +89,43 @@ var _ = Describe("Parser", func() {
 				"foo()":        "foo()",
 				"foo(1, 2, 3)": "foo(1, 2, 3)",
 				`"foo bar"`:    `"foo bar"`,
+
+				// Modules.
+				`import "lib/math"`:                    `import "lib/math";`,
+				`import "lib/vec2"`:                    `import "lib/vec2";`,
+				`import "lib/math.mk"; math.add(1, 2)`: `import "lib/math.mk";math.add(1, 2)`,
+				`import { add, sub } from "math";`:     `import { add, sub } from "math";`,
+				"export let a = 1":                     "export let a = 1;",
+				"a.b.c + d.e()":                        "(a.b.c + d.e())",
+
+				// Floats.
+				"1.5 + 2 * 0.25": "(1.5 + (2 * 0.25))",
+				"-1_000.5":       "(-1_000.5)",
+
+				// Arrays.
+				"[]":                  "[]",
+				"[1, 2 * 3, \"a\"]":   `[1, (2 * 3), "a"]`,
+				"a[1 + 2][0]":         "((a[(1 + 2)])[0])",
+				"-a[0] * b.c[1](2)":   "((-(a[0])) * (b.c[1])(2))",
+				"[fn(x) { x }][0](1)": "([fn(x) { x }][0])(1)",
+
+				// Hashes.
+				"{}":                          "{}",
+				`{"a": 1 + 2, b: [1], 3: {}}`: `{"a": (1 + 2), b: [1], 3: {}}`,
+				`{"a": 1,}["a"]`:              `({"a": 1}["a"])`,
+				"if (a) { {1: 2} }":           "if a { {1: 2} }",
+
+				// Errors.
+				`throw "boom"`: `throw "boom";`,
+				"try { a } catch (e) { throw e; } finally { b }": "try { a } catch (e) { throw e; } finally { b }",
+				"try { } finally { }":                            "try { } finally { }",
+				"let a = try { f() } catch (e) { e.message }":    "let a = try { f() } catch (e) { e.message };",
+
+				// Comments.
+				"// comment\nlet a = 5; // trailing": "let a = 5;",
+				"a /* inline /* nested */ */ + b":    "(a + b)",
+				"// only comments":                   "",
+				"":                                   "",
 			}
 
 			for input, output := range cases {
@@ -98,7 +144,77 @@ var _ = Describe("Parser", func() {
 		})
 
 		Context("when program is invalid", func() {
-			// TODO: write me
+			cases := map[string]string{
+				"let 1":                       "1:5: invalid token. Expected: IDENTIFIER, found: INTEGER(1)",
+				"1 +\n  )":                    "2:3: no prefix parse function found for )",
+				"let a = 1 $ 2":               "1:11: reading next token error: illegal character: '$'",
+				"fn() { import \"a\" }":       "1:8: statement is only allowed at the top level: import",
+				"if (a) { export let a = 1 }": "1:10: statement is only allowed at the top level: export",
+				`import "lib/my-mod"`:         "1:8: module name is not a valid identifier: my-mod",
+				`import "lib/2d"`:             "1:8: module name is not a valid identifier: 2d",
+				`import "lib/if"`:             "1:8: module name is not a valid identifier: if",
+				`import {} from "a"`:          "1:9: invalid token. Expected: IDENTIFIER, found: }(})",
+				`import { a b } from "a"`:     "1:12: invalid token. Expected: }, found: IDENTIFIER(b)",
+				"a.1":                         "1:3: invalid token. Expected: IDENTIFIER, found: INTEGER(1)",
+				`{"a" 1}`:                     "1:6: invalid token. Expected: :, found: INTEGER(1)",
+				`{"a": 1 "b": 2}`:             `1:9: invalid token. Expected: ,, found: STRING(b)`,
+				"a[1, 2]":                     "1:4: invalid token. Expected: ], found: ,(,)",
+				"try { a }; b":                "1:10: try must be followed by catch or finally",
+				"try { a } catch { b }":       "1:17: invalid token. Expected: (, found: {({)",
+				"try { a } catch (1) { b }":   "1:18: invalid token. Expected: IDENTIFIER, found: INTEGER(1)",
+			}
+
+			for input, message := range cases {
+				Context("when parsing "+input, func() {
+					It("returns a positioned error", func() {
+						_, err := parser.New(lexer.New(input)).ParseProgram()
+
+						var parserErr *parser.Error
+
+						Expect(errors.As(err, &parserErr)).To(BeTrue())
+						Expect(err).To(MatchError(message))
+					})
+				})
+			}
+		})
+
+		Context("when program is truncated", func() {
+			cases := []string{"1 +", "foo(1,", "(1", "let", "let a =", "fn(x) {", "if (x", "[1, 2", "a[1", "{", `{"a": 1`, `{"a"`, "try { a }", "throw"}
+
+			for _, input := range cases {
+				Context("when parsing "+input, func() {
+					It("returns an end of input error", func() {
+						_, err := parser.New(lexer.New(input)).ParseProgram()
+						Expect(err).To(Or(MatchError(io.EOF), MatchError(parser.ErrUnexpectedEOF)))
+					})
+				})
+			}
+		})
+
+		Context("when program is parsed", func() {
+			It("sets node positions", func() {
+				input := "let a = 1 + 2;\nfoo(a, fn(x) {\n  x\n})"
+
+				program, err := parser.New(lexer.New(input)).ParseProgram()
+				Expect(err).ToNot(HaveOccurred())
+
+				source := func(node ast.Node) string {
+					return input[node.Pos().Offset:node.End().Offset]
+				}
+
+				let := program.Statements[0].(*ast.LetStatement)
+				call := program.Statements[1].(*ast.ExpressionStatement).V.(*ast.CallExpression)
+				function := call.Arguments[1].(*ast.FunctionExpression)
+
+				Expect(source(let)).To(Equal("let a = 1 + 2;"))
+				Expect(source(let.Name)).To(Equal("a"))
+				Expect(source(let.V)).To(Equal("1 + 2"))
+				Expect(source(call)).To(Equal("foo(a, fn(x) {\n  x\n})"))
+				Expect(source(function)).To(Equal("fn(x) {\n  x\n}"))
+				Expect(source(function.V)).To(Equal("{\n  x\n}"))
+				Expect(function.V.V[0].Pos().String()).To(Equal("3:3"))
+				Expect(source(program)).To(Equal(input))
+			})
 		})
 	})
 })
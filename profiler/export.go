@@ -0,0 +1,111 @@
+package profiler
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/google/pprof/profile"
+	"github.com/samber/lo"
+)
+
+// WriteText writes a table of function statistics.
+func (p *Profiler) WriteText(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(table, "calls\tcumulative\tself\talloc bytes\talloc objects\t\tfunction")
+
+	for _, function := range p.Functions() {
+		fmt.Fprintf(table, "%d\t%s\t%s\t%d\t%d\t\t%s\n",
+			function.Calls, function.Cumulative, function.Self, function.AllocBytes, function.AllocObjects, function)
+	}
+
+	return table.Flush() //nolint:wrapcheck
+}
+
+// WriteFolded writes stacks in the folded format accepted by flame graph tools: frames from the root
+// separated by semicolons followed by the self time of the stack in nanoseconds.
+func (p *Profiler) WriteFolded(w io.Writer) error {
+	keys := lo.Keys(p.stacks)
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		_, err := fmt.Fprintf(w, "%s %d\n", key, p.stacks[key].stats.Self.Nanoseconds())
+		if err != nil {
+			return fmt.Errorf("writing profile error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// WritePprof writes a gzipped protobuf profile readable by `go tool pprof`. Each stack is a sample with calls,
+// self time and allocations, time is the default sample type.
+func (p *Profiler) WritePprof(w io.Writer) error {
+	prof := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "calls", Unit: "count"},
+			{Type: "time", Unit: "nanoseconds"},
+			{Type: "alloc_space", Unit: "bytes"},
+			{Type: "alloc_objects", Unit: "count"},
+		},
+		DefaultSampleType: "time",
+		PeriodType:        &profile.ValueType{Type: "time", Unit: "nanoseconds"},
+		Period:            1,
+	}
+
+	locations := map[Function]*profile.Location{}
+
+	location := func(function Function) *profile.Location {
+		if loc, ok := locations[function]; ok {
+			return loc
+		}
+
+		fn := &profile.Function{
+			ID:         uint64(len(prof.Function) + 1),
+			Name:       function.Name,
+			SystemName: function.String(),
+			Filename:   p.filename,
+			StartLine:  int64(function.Pos.Line),
+		}
+		loc := &profile.Location{
+			ID:   uint64(len(prof.Location) + 1),
+			Line: []profile.Line{{Function: fn, Line: int64(function.Pos.Line)}},
+		}
+
+		prof.Function = append(prof.Function, fn)
+		prof.Location = append(prof.Location, loc)
+		locations[function] = loc
+
+		return loc
+	}
+
+	keys := lo.Keys(p.stacks)
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		stack := p.stacks[key]
+
+		prof.Sample = append(prof.Sample, &profile.Sample{
+			Location: lo.Map(stack.functions, func(function Function, _ int) *profile.Location { return location(function) }),
+			Value: []int64{
+				int64(stack.stats.Calls),
+				stack.stats.Self.Nanoseconds(),
+				int64(stack.stats.AllocBytes),   //nolint:gosec
+				int64(stack.stats.AllocObjects), //nolint:gosec
+			},
+		})
+
+		if len(stack.functions) == 1 {
+			prof.DurationNanos += stack.stats.Cumulative.Nanoseconds()
+		}
+	}
+
+	err := prof.Write(w)
+	if err != nil {
+		return fmt.Errorf("writing profile error: %w", err)
+	}
+
+	return nil
+}
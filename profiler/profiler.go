@@ -0,0 +1,209 @@
+// Package profiler records where Monkey programs spend time and memory, per function of the program.
+package profiler
+
+import (
+	"cmp"
+	"runtime/metrics"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zhulik/monkey/evaluator"
+	"github.com/zhulik/monkey/tokens"
+)
+
+type Option func(*Profiler)
+
+// WithFilename sets the name of the profiled source file, it's used in exported profiles.
+func WithFilename(filename string) Option {
+	return func(p *Profiler) {
+		p.filename = filename
+	}
+}
+
+// WithClock replaces time.Now, it makes profiles deterministic in tests.
+func WithClock(now func() time.Time) Option {
+	return func(p *Profiler) {
+		p.now = now
+	}
+}
+
+// Function identifies a function of the program, the main frame has zero position.
+type Function struct {
+	Name string
+	Pos  tokens.Position
+}
+
+func (f Function) String() string {
+	if f.Pos == (tokens.Position{}) {
+		return f.Name
+	}
+
+	return f.Name + "@" + f.Pos.String()
+}
+
+// Stats are totals for a function or a stack. Self values exclude callees, cumulative time includes
+// them and counts recursive calls once.
+type Stats struct {
+	Calls        int
+	Cumulative   time.Duration
+	Self         time.Duration
+	AllocBytes   uint64
+	AllocObjects uint64
+}
+
+type FunctionStats struct {
+	Function
+	Stats
+}
+
+// Profiler is an evaluator.Tracer, it's not safe for concurrent use by several evaluators.
+type Profiler struct {
+	filename string
+	now      func() time.Time
+
+	functions map[Function]*Stats
+	stacks    map[string]*stack
+	active    []*activation
+	samples   []metrics.Sample
+}
+
+type stack struct {
+	functions []Function // leaf first
+	stats     Stats
+}
+
+// activation is a call in progress.
+type activation struct {
+	function  Function
+	stack     string
+	recursive bool
+
+	start        time.Time
+	startBytes   uint64
+	startObjects uint64
+	childTime    time.Duration
+	childBytes   uint64
+	childObjects uint64
+}
+
+func New(opts ...Option) *Profiler {
+	profiler := &Profiler{
+		now:       time.Now,
+		functions: map[Function]*Stats{},
+		stacks:    map[string]*stack{},
+		samples: []metrics.Sample{
+			{Name: "/gc/heap/allocs:bytes"},
+			{Name: "/gc/heap/allocs:objects"},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(profiler)
+	}
+
+	return profiler
+}
+
+// Option returns the evaluator option which enables profiling.
+func (p *Profiler) Option() evaluator.Option {
+	return evaluator.WithTracer(p)
+}
+
+func (p *Profiler) Enter(frame evaluator.Frame) {
+	function := Function{Name: frame.Name}
+	if frame.Function != nil {
+		function.Pos = frame.Function.Pos()
+	}
+
+	call := &activation{function: function, stack: function.String()}
+
+	functions := []Function{function}
+
+	if len(p.active) > 0 {
+		parent := p.active[len(p.active)-1]
+		call.stack = parent.stack + ";" + call.stack
+		functions = append(functions, p.stacks[parent.stack].functions...)
+	}
+
+	call.recursive = slices.ContainsFunc(p.active, func(a *activation) bool { return a.function == function })
+
+	if _, ok := p.functions[function]; !ok {
+		p.functions[function] = &Stats{}
+	}
+
+	if _, ok := p.stacks[call.stack]; !ok {
+		p.stacks[call.stack] = &stack{functions: functions}
+	}
+
+	p.functions[function].Calls++
+	p.stacks[call.stack].stats.Calls++
+
+	p.active = append(p.active, call)
+
+	// Measure last, so the profiler's own work is not accounted to the function.
+	call.startBytes, call.startObjects = p.allocs()
+	call.start = p.now()
+}
+
+func (p *Profiler) Exit(_ evaluator.Frame) {
+	end := p.now()
+	bytes, objects := p.allocs()
+
+	call := p.active[len(p.active)-1]
+	p.active = p.active[:len(p.active)-1]
+
+	total := end.Sub(call.start)
+	totalBytes := bytes - call.startBytes
+	totalObjects := objects - call.startObjects
+
+	self := Stats{
+		Self:         total - call.childTime,
+		AllocBytes:   totalBytes - min(call.childBytes, totalBytes),
+		AllocObjects: totalObjects - min(call.childObjects, totalObjects),
+	}
+
+	for _, stats := range []*Stats{p.functions[call.function], &p.stacks[call.stack].stats} {
+		stats.Self += self.Self
+		stats.AllocBytes += self.AllocBytes
+		stats.AllocObjects += self.AllocObjects
+	}
+
+	if !call.recursive {
+		p.functions[call.function].Cumulative += total
+	}
+
+	p.stacks[call.stack].stats.Cumulative += total
+
+	if len(p.active) > 0 {
+		parent := p.active[len(p.active)-1]
+		parent.childTime += total
+		parent.childBytes += totalBytes
+		parent.childObjects += totalObjects
+	}
+}
+
+// Functions returns statistics of every called function, the most expensive by self time go first.
+func (p *Profiler) Functions() []FunctionStats {
+	result := make([]FunctionStats, 0, len(p.functions))
+
+	for function, stats := range p.functions {
+		result = append(result, FunctionStats{Function: function, Stats: *stats})
+	}
+
+	slices.SortFunc(result, func(a, b FunctionStats) int {
+		if a.Self != b.Self {
+			return cmp.Compare(b.Self, a.Self)
+		}
+
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return result
+}
+
+func (p *Profiler) allocs() (uint64, uint64) {
+	metrics.Read(p.samples)
+
+	return p.samples[0].Value.Uint64(), p.samples[1].Value.Uint64()
+}
@@ -0,0 +1,136 @@
+package profiler_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/google/pprof/profile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/evaluator"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/profiler"
+	"github.com/zhulik/monkey/tokens"
+)
+
+// fakeClock advances by a millisecond on every reading.
+func fakeClock() func() time.Time {
+	now := time.Unix(0, 0)
+
+	return func() time.Time {
+		now = now.Add(time.Millisecond)
+
+		return now
+	}
+}
+
+func run(source string, opts ...profiler.Option) *profiler.Profiler {
+	prof := profiler.New(opts...)
+
+	_, err := evaluator.New(prof.Option()).Eval(lo.Must(parser.New(lexer.New(source)).ParseProgram()))
+	Expect(err).ToNot(HaveOccurred())
+
+	return prof
+}
+
+func stats(calls int, cumulative, self time.Duration) profiler.Stats {
+	return profiler.Stats{Calls: calls, Cumulative: cumulative, Self: self}
+}
+
+var _ = Describe("Profiler", func() {
+	source := "let double = fn(x) { x * 2 };\nlet quad = fn(x) { double(double(x)) };\nquad(1)"
+
+	withoutAllocs := func(functions []profiler.FunctionStats) []profiler.FunctionStats {
+		return lo.Map(functions, func(function profiler.FunctionStats, _ int) profiler.FunctionStats {
+			function.AllocBytes = 0
+			function.AllocObjects = 0
+
+			return function
+		})
+	}
+
+	It("records calls, cumulative and self time per function", func() {
+		prof := run(source, profiler.WithClock(fakeClock()))
+
+		Expect(withoutAllocs(prof.Functions())).To(Equal([]profiler.FunctionStats{
+			{Function: profiler.Function{Name: "quad", Pos: tokens.Position{Offset: 41, Line: 2, Column: 12}}, Stats: stats(1, 5*time.Millisecond, 3*time.Millisecond)},
+			{Function: profiler.Function{Name: "double", Pos: tokens.Position{Offset: 13, Line: 1, Column: 14}}, Stats: stats(2, 2*time.Millisecond, 2*time.Millisecond)},
+			{Function: profiler.Function{Name: "main"}, Stats: stats(1, 7*time.Millisecond, 2*time.Millisecond)},
+		}))
+	})
+
+	It("counts cumulative time of recursive calls once", func() {
+		prof := run("let fib = fn(n) { if (n < 2) { return n } fib(n - 1) + fib(n - 2) }; fib(2)", profiler.WithClock(fakeClock()))
+
+		fib := prof.Functions()[0]
+		Expect(fib.Name).To(Equal("fib"))
+		Expect(fib.Stats.Calls).To(Equal(3))
+		Expect(fib.Cumulative).To(Equal(5 * time.Millisecond))
+		Expect(fib.Self).To(Equal(5 * time.Millisecond))
+	})
+
+	It("records allocations", func() {
+		prof := run("let f = fn(n) { if (n == 0) { return 0 } \"a\" + \"b\"; f(n - 1) }; f(100)")
+
+		f, _ := lo.Find(prof.Functions(), func(function profiler.FunctionStats) bool { return function.Name == "f" })
+		Expect(f.AllocObjects).To(BeNumerically(">", 0))
+		Expect(f.AllocBytes).To(BeNumerically(">", 0))
+	})
+
+	It("writes folded stacks", func() {
+		prof := run(source, profiler.WithClock(fakeClock()))
+
+		out := &bytes.Buffer{}
+		Expect(prof.WriteFolded(out)).To(Succeed())
+		Expect(out.String()).To(Equal("main 2000000\nmain;quad@2:12 3000000\nmain;quad@2:12;double@1:14 2000000\n"))
+	})
+
+	It("writes a text report", func() {
+		prof := run(source, profiler.WithClock(fakeClock()))
+
+		out := &bytes.Buffer{}
+		Expect(prof.WriteText(out)).To(Succeed())
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).To(HaveLen(4))
+		Expect(lines[0]).To(MatchRegexp(`calls\s+cumulative\s+self\s+alloc bytes\s+alloc objects\s+function`))
+		Expect(lines[1]).To(MatchRegexp(`^\s+1\s+5ms\s+3ms\s+\d+\s+\d+\s+quad@2:12$`))
+	})
+
+	It("writes a pprof profile", func() {
+		prof := run(source, profiler.WithClock(fakeClock()), profiler.WithFilename("main.mk"))
+
+		out := &bytes.Buffer{}
+		Expect(prof.WritePprof(out)).To(Succeed())
+
+		parsed, err := profile.Parse(out)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.CheckValid()).To(Succeed())
+
+		Expect(lo.Map(parsed.SampleType, func(t *profile.ValueType, _ int) string { return t.Type })).To(Equal([]string{
+			"calls", "time", "alloc_space", "alloc_objects",
+		}))
+		Expect(parsed.DurationNanos).To(Equal(int64(7 * time.Millisecond)))
+
+		samples := map[string][]int64{}
+
+		for _, sample := range parsed.Sample {
+			names := lo.Map(sample.Location, func(loc *profile.Location, _ int) string {
+				fn := loc.Line[0].Function
+
+				return fn.Name + ":" + fn.Filename
+			})
+
+			samples[lo.Must(lo.Last(names))+" "+names[0]] = sample.Value[:2]
+		}
+
+		Expect(samples).To(Equal(map[string][]int64{
+			"main:main.mk main:main.mk":   {1, int64(2 * time.Millisecond)},
+			"main:main.mk quad:main.mk":   {1, int64(3 * time.Millisecond)},
+			"main:main.mk double:main.mk": {2, int64(2 * time.Millisecond)},
+		}))
+	})
+})
@@ -0,0 +1,230 @@
+package repl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	obj "github.com/zhulik/monkey/evaluator/object"
+	"github.com/zhulik/monkey/lexer"
+)
+
+const commandPrefix = ":"
+
+var ErrMissingArgument = errors.New("missing argument")
+
+type command struct {
+	usage string
+	// code is set for commands which take Monkey code as the argument, such input may span multiple lines.
+	code bool
+	run  func(arg string) error
+}
+
+func (r *Repl) buildCommands() map[string]command {
+	return map[string]command{
+		"help":   {usage: ":help - show this help", run: r.helpCommand},
+		"tokens": {usage: ":tokens <code> - show lexer output", code: true, run: r.tokensCommand},
+		"ast":    {usage: ":ast <code> - show the syntax tree", code: true, run: r.astCommand},
+		"env":    {usage: ":env - list bindings in the session environment", run: r.envCommand},
+		"type":   {usage: ":type <code> - evaluate code and show the type of the result", code: true, run: r.typeCommand},
+		"load":   {usage: ":load <file> - evaluate a file in the session environment", run: r.loadCommand},
+		"reset":  {usage: ":reset - clear the session environment", run: r.resetCommand},
+		"time":   {usage: ":time <code> - evaluate code and show how long it took", code: true, run: r.timeCommand},
+		"save":   {usage: ":save [file] - save let statements of the session to a file", run: r.saveCommand},
+		"restore": {
+			usage: ":restore [file] - evaluate a file saved with :save in the session environment",
+			run:   r.restoreCommand,
+		},
+	}
+}
+
+func parseCommand(input string) (string, string, bool) {
+	input = strings.TrimSpace(input)
+
+	if !strings.HasPrefix(input, commandPrefix) {
+		return "", "", false
+	}
+
+	name, arg, _ := strings.Cut(input[len(commandPrefix):], " ")
+
+	return name, strings.TrimSpace(arg), true
+}
+
+func (r *Repl) runCommand(name, arg string) {
+	cmd, ok := r.commands[name]
+	if !ok {
+		r.printf("Unknown command :%s, type :help for the list of commands\n", name)
+
+		return
+	}
+
+	err := cmd.run(arg)
+	if err != nil {
+		r.printf("%s\n", err.Error())
+	}
+}
+
+func (r *Repl) helpCommand(_ string) error {
+	names := lo.Keys(r.commands)
+	slices.Sort(names)
+
+	for _, name := range names {
+		r.printf("%s\n", r.commands[name].usage)
+	}
+
+	return nil
+}
+
+func (r *Repl) tokensCommand(arg string) error {
+	for token, err := range lexer.New(arg).IterateTokens() {
+		if err != nil {
+			return fmt.Errorf("Lexing error: %w", err) //nolint:stylecheck
+		}
+
+		if token.Literal() == string(token.Type) {
+			r.printf("%s\n", token.Type)
+		} else {
+			r.printf("%s(%s)\n", token.Type, token.Literal())
+		}
+	}
+
+	return nil
+}
+
+func (r *Repl) astCommand(arg string) error {
+	program, err := parse(arg)
+	if err != nil {
+		return err
+	}
+
+	printTree(r.out, program)
+
+	return nil
+}
+
+func (r *Repl) envCommand(_ string) error {
+	for _, name := range r.env.Names() {
+		value := lo.Must(r.env.Get(name))
+
+		r.printf("%s: %s = %s\n", name, value.TypeName(), value.Inspect())
+	}
+
+	return nil
+}
+
+func (r *Repl) typeCommand(arg string) error {
+	result, err := r.run(arg)
+	if err != nil {
+		return err
+	}
+
+	r.printf("%s\n", result.TypeName())
+
+	return nil
+}
+
+func (r *Repl) loadCommand(arg string) error {
+	if arg == "" {
+		return fmt.Errorf("%w: file name", ErrMissingArgument)
+	}
+
+	source, err := os.ReadFile(arg)
+	if err != nil {
+		return fmt.Errorf("Loading error: %w", err) //nolint:stylecheck
+	}
+
+	result, err := r.run(string(source))
+	if err != nil {
+		return err
+	}
+
+	r.printf("%s\n", result.Inspect())
+
+	return nil
+}
+
+func (r *Repl) resetCommand(_ string) error {
+	r.env = obj.NewEnv()
+	r.definitions = nil
+
+	return nil
+}
+
+func (r *Repl) timeCommand(arg string) error {
+	start := time.Now()
+
+	result, err := r.run(arg)
+	if err != nil {
+		return err
+	}
+
+	r.printf("%s\n", result.Inspect())
+	r.printf("Time: %s\n", time.Since(start))
+
+	return nil
+}
+
+func (r *Repl) saveCommand(arg string) error {
+	path, err := r.sessionPath(arg)
+	if err != nil {
+		return err
+	}
+
+	source := strings.Builder{}
+
+	for _, definition := range r.definitions {
+		source.WriteString(definition)
+
+		if !strings.HasSuffix(definition, ";") {
+			source.WriteString(";")
+		}
+
+		source.WriteString("\n")
+	}
+
+	err = os.WriteFile(path, []byte(source.String()), 0o600)
+	if err != nil {
+		return fmt.Errorf("Saving error: %w", err) //nolint:stylecheck
+	}
+
+	r.printf("Saved %d definitions to %s\n", len(r.definitions), path)
+
+	return nil
+}
+
+func (r *Repl) restoreCommand(arg string) error {
+	path, err := r.sessionPath(arg)
+	if err != nil {
+		return err
+	}
+
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Restoring error: %w", err) //nolint:stylecheck
+	}
+
+	_, err = r.run(string(source))
+	if err != nil {
+		return err
+	}
+
+	r.printf("Restored session from %s\n", path)
+
+	return nil
+}
+
+func (r *Repl) sessionPath(arg string) (string, error) {
+	if arg != "" {
+		return arg, nil
+	}
+
+	if r.sessionFile == "" {
+		return "", fmt.Errorf("%w: file name", ErrMissingArgument)
+	}
+
+	return r.sessionFile, nil
+}
@@ -0,0 +1,68 @@
+package repl
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	obj "github.com/zhulik/monkey/evaluator/object"
+	"github.com/zhulik/monkey/tokens"
+)
+
+type completer struct {
+	repl *Repl
+}
+
+func (c completer) Do(line []rune, pos int) ([][]rune, int) {
+	prefix := string(line[:pos])
+
+	start := strings.LastIndexFunc(prefix, func(r rune) bool {
+		return !isIdentifierRune(r)
+	}) + 1
+
+	word := prefix[start:]
+
+	return lo.Map(c.repl.Complete(prefix[:start], word), func(candidate string, _ int) []rune {
+		return []rune(candidate[len(word):])
+	}), len([]rune(word))
+}
+
+// Complete returns sorted candidates starting with word. Meta-command names are completed at the beginning
+// of the line, otherwise keywords, builtins and names bound in the session environment and its parents.
+func (r *Repl) Complete(before, word string) []string {
+	var candidates []string
+
+	if before == commandPrefix {
+		candidates = lo.Keys(r.commands)
+	} else {
+		candidates = lo.Map(tokens.Keywords(), func(keyword tokens.TokenType, _ int) string {
+			return string(keyword)
+		})
+
+		candidates = append(candidates, r.eval.Builtins()...)
+
+		var env obj.EnvGetter = r.env
+
+		for env != nil {
+			current, ok := env.(*obj.Env)
+			if !ok {
+				break
+			}
+
+			candidates = append(candidates, current.Names()...)
+			env = current.Parent()
+		}
+	}
+
+	candidates = lo.Uniq(lo.Filter(candidates, func(candidate string, _ int) bool {
+		return strings.HasPrefix(candidate, word)
+	}))
+
+	slices.Sort(candidates)
+
+	return candidates
+}
+
+func isIdentifierRune(r rune) bool {
+	return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || r == '_' || '0' <= r && r <= '9'
+}
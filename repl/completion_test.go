@@ -0,0 +1,48 @@
+package repl_test
+
+import (
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zhulik/monkey/repl"
+)
+
+var _ = Describe("Completion", func() {
+	var rpl *repl.Repl
+
+	BeforeEach(func() {
+		rpl = repl.New(io.Discard)
+		rpl.Execute("let result = 1; let retry = fn() { 1 };")
+	})
+
+	Describe(".Complete", func() {
+		It("completes keywords, builtins and bound names", func() {
+			Expect(rpl.Complete("", "re")).To(Equal([]string{"reduce", "result", "retry", "return"}))
+			Expect(rpl.Complete("1 + ", "f")).To(Equal([]string{"false", "filter", "finally", "find", "fn", "from"}))
+		})
+
+		It("completes meta-commands at the beginning of the line", func() {
+			Expect(rpl.Complete(":", "t")).To(Equal([]string{"time", "tokens", "type"}))
+		})
+
+		It("returns nothing when nothing matches", func() {
+			Expect(rpl.Complete("", "zzz")).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("Highlight", func() {
+	cases := map[string]string{
+		`let a = 1; // one`: "\033[35mlet\033[0m a = \033[36m1\033[0m; \033[90m// one\033[0m",
+		`"foo" + a$`:        "\033[32m\"foo\"\033[0m + a\033[31;4m$\033[0m",
+		`if (x) { "unterm`:  "\033[35mif\033[0m (x) { \033[31;4m\"unterm\033[0m",
+		`:ast  nil`:         "\033[1m:ast\033[0m  \033[35mnil\033[0m",
+	}
+
+	for input, output := range cases {
+		It("colours "+input, func() {
+			Expect(repl.Highlight(input)).To(Equal(output))
+		})
+	}
+})
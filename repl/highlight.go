@@ -0,0 +1,90 @@
+package repl
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/tokens"
+)
+
+const (
+	colorReset   = "\033[0m"
+	colorKeyword = "\033[35m"
+	colorNumber  = "\033[36m"
+	colorString  = "\033[32m"
+	colorComment = "\033[90m"
+	colorCommand = "\033[1m"
+	colorError   = "\033[31;4m"
+)
+
+type highlighter struct{}
+
+func (highlighter) Paint(line []rune, _ int) []rune {
+	return []rune(Highlight(string(line)))
+}
+
+// Highlight colours the line with ANSI escape codes based on token types, input the lexer rejects is underlined red.
+func Highlight(line string) string {
+	if strings.HasPrefix(line, commandPrefix) {
+		name, arg, found := strings.Cut(line, " ")
+		if !found {
+			return colorCommand + name + colorReset
+		}
+
+		return colorCommand + name + colorReset + " " + Highlight(arg)
+	}
+
+	out := strings.Builder{}
+	lex := lexer.New(line, lexer.WithComments())
+	written := 0
+
+	for {
+		token, err := lex.NextToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		span := lex.Span()
+		start, end := min(span.Start.Offset, len(line)), min(span.End.Offset, len(line))
+
+		color := tokenColor(token)
+		if err != nil {
+			color = colorError
+		}
+
+		out.WriteString(line[written:start])
+
+		if color == "" {
+			out.WriteString(line[start:end])
+		} else {
+			out.WriteString(color + line[start:end] + colorReset)
+		}
+
+		written = end
+
+		if errors.Is(err, lexer.ErrUnterminatedString) || errors.Is(err, lexer.ErrUnterminatedComment) {
+			break
+		}
+	}
+
+	out.WriteString(line[written:])
+
+	return out.String()
+}
+
+func tokenColor(token tokens.Token) string {
+	switch {
+	case tokens.IsKeyword(token.Type):
+		return colorKeyword
+	case token.Type == tokens.INTEGER:
+		return colorNumber
+	case token.Type == tokens.STRING:
+		return colorString
+	case token.Type == tokens.COMMENT:
+		return colorComment
+	default:
+		return ""
+	}
+}
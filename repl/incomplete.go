@@ -0,0 +1,43 @@
+package repl
+
+import (
+	"errors"
+	"io"
+
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/tokens"
+)
+
+// Incomplete reports whether the input needs more lines before it can be evaluated:
+// it has unbalanced braces or parens, an unterminated string or comment, or the parser ran out of tokens.
+func Incomplete(input string) bool {
+	depth, count := 0, 0
+
+	for token, err := range lexer.New(input).IterateTokens() {
+		count++
+
+		if err != nil {
+			return errors.Is(err, lexer.ErrUnterminatedString) || errors.Is(err, lexer.ErrUnterminatedComment)
+		}
+
+		switch token.Type { //nolint:exhaustive
+		case tokens.LPAREN, tokens.LBRACE:
+			depth++
+		case tokens.RPAREN, tokens.RBRACE:
+			depth--
+		}
+	}
+
+	if depth > 0 {
+		return true
+	}
+
+	if depth < 0 || count == 0 {
+		return false
+	}
+
+	_, err := parser.New(lexer.New(input)).ParseProgram()
+
+	return errors.Is(err, io.EOF) || errors.Is(err, parser.ErrUnexpectedEOF)
+}
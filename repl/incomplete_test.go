@@ -0,0 +1,33 @@
+package repl_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zhulik/monkey/repl"
+)
+
+var _ = Describe("Incomplete", func() {
+	cases := map[string]bool{
+		"":                              false,
+		"// just a comment":             false,
+		"1 + 1":                         false,
+		"let f = fn(x) { x };":          false,
+		"let f = fn(x) {\n  x\n};":      false,
+		"1)":                            false,
+		"let f = fn(x) {":               true,
+		"let f = fn(x) {\n  if (x > 1)": true,
+		"add(1,\n":                      true,
+		`"unterminated`:                 true,
+		"/* open comment":               true,
+		"let a =":                       true,
+		"if (true) { 1 } else":          true,
+	}
+
+	for input, result := range cases {
+		It(fmt.Sprintf("returns %t for %q", result, input), func() {
+			Expect(repl.Incomplete(input)).To(Equal(result))
+		})
+	}
+})
@@ -4,32 +4,116 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/chzyer/readline"
 	"github.com/k0kubun/pp"
+	"github.com/zhulik/monkey/ast"
 	"github.com/zhulik/monkey/evaluator"
 	obj "github.com/zhulik/monkey/evaluator/object"
 	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/module"
 	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/stdlib"
 )
 
+const (
+	prompt             = ">> "
+	continuationPrompt = ".. "
+)
+
+type Option func(*Repl)
+
+// WithSessionFile sets the default file for :save and :restore.
+func WithSessionFile(path string) Option {
+	return func(r *Repl) {
+		r.sessionFile = path
+	}
+}
+
+// WithFS enables imports of modules from the filesystem, modules of the standard library are always available.
+func WithFS(fsys fs.FS) Option {
+	return func(r *Repl) {
+		r.evalOpts = append(r.evalOpts, module.New(fsys).Option())
+	}
+}
+
+type Repl struct {
+	out      io.Writer
+	eval     evaluator.Evaluator
+	evalOpts []evaluator.Option
+	env      *obj.Env
+	commands map[string]command
+
+	// definitions holds the source of top-level let and import statements evaluated in the session, in order.
+	definitions []string
+	sessionFile string
+}
+
+func New(out io.Writer, opts ...Option) *Repl {
+	repl := &Repl{
+		out:      out,
+		evalOpts: stdlib.Options(),
+		env:      obj.NewEnv(),
+	}
+
+	for _, opt := range opts {
+		opt(repl)
+	}
+
+	repl.eval = evaluator.New(repl.evalOpts...)
+
+	repl.commands = repl.buildCommands()
+
+	return repl
+}
+
 func Start() error {
-	pp.Printf("Monkey repl.\n")
+	opts := []Option{WithFS(os.DirFS("."))}
+	historyFile := ""
 
-	eval := evaluator.New()
+	dir, err := configDir()
+	if err == nil {
+		historyFile = filepath.Join(dir, "history")
+		opts = append(opts, WithSessionFile(filepath.Join(dir, "session.mk")))
+	}
 
-	rln, err := readline.New(">> ")
+	// The completer gets the repl once it's created, the repl writes to readline's stdout so the output doesn't
+	// break the prompt.
+	comp := &completer{}
+
+	rln, err := readline.NewEx(&readline.Config{
+		Prompt:       prompt,
+		HistoryFile:  historyFile,
+		AutoComplete: comp,
+		Painter:      highlighter{},
+	})
 	if err != nil {
 		return fmt.Errorf("readline init error: %w", err)
 	}
 
 	defer rln.Close()
 
-	environment := obj.NewEnv()
+	repl := New(rln.Stdout(), opts...)
+	comp.repl = repl
+
+	input := strings.Builder{}
+
+	pp.Fprintf(repl.out, "Monkey repl. Type :help for the list of commands.\n") //nolint:errcheck
 
 	for {
 		line, rErr := rln.Readline()
 		if rErr != nil {
+			if errors.Is(rErr, readline.ErrInterrupt) && input.Len() > 0 {
+				input.Reset()
+				rln.SetPrompt(prompt)
+
+				continue
+			}
+
 			if errors.Is(rErr, io.EOF) || errors.Is(rErr, readline.ErrInterrupt) {
 				return nil
 			}
@@ -37,28 +121,108 @@ func Start() error {
 			return fmt.Errorf("readline error: %w", rErr)
 		}
 
-		lex := lexer.New(line)
-		// TODO: use rangefunc when golangci-lint implemetns proper support
-		// for _, token := range lex.IterateTokens() {
-		// 	fmt.Printf("%+v\n", token)
-		// }
+		input.WriteString(line + "\n")
 
-		parser := parser.New(lex)
-
-		program, pErr := parser.ParseProgram()
-		if pErr != nil {
-			fmt.Printf("Parsing error: %s\n", pErr.Error()) //nolint:forbidigo
+		if repl.Incomplete(input.String()) {
+			rln.SetPrompt(continuationPrompt)
 
 			continue
 		}
 
-		result, eErr := eval.Eval(program, environment)
-		if eErr != nil {
-			fmt.Printf("Evaluation error: %s\n", eErr.Error()) //nolint:forbidigo
+		source := input.String()
 
-			continue
+		input.Reset()
+		rln.SetPrompt(prompt)
+
+		repl.Execute(source)
+	}
+}
+
+// Incomplete reports whether the input needs more lines, meta-commands which take code are checked by their argument.
+func (r *Repl) Incomplete(input string) bool {
+	if name, arg, ok := parseCommand(input); ok {
+		cmd, found := r.commands[name]
+
+		return found && cmd.code && Incomplete(arg)
+	}
+
+	return Incomplete(input)
+}
+
+// Execute runs a meta-command or evaluates the source in the session environment and prints the result.
+func (r *Repl) Execute(source string) {
+	if strings.TrimSpace(source) == "" {
+		return
+	}
+
+	if name, arg, ok := parseCommand(source); ok {
+		r.runCommand(name, arg)
+
+		return
+	}
+
+	result, err := r.run(source)
+	if err != nil {
+		r.printf("%s\n", err.Error())
+
+		return
+	}
+
+	pp.Fprintln(r.out, result.Inspect()) //nolint:errcheck
+}
+
+// run evaluates the source statement by statement so the source of every successfully evaluated
+// let statement can be recorded for :save.
+func (r *Repl) run(source string) (obj.Object, error) {
+	program, err := parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var result obj.Object = obj.NIL
+
+	for _, stmt := range program.Statements {
+		result, err = r.eval.Eval(&ast.Program{Statements: []ast.Statement{stmt}}, r.env)
+		if err != nil {
+			return nil, fmt.Errorf("Evaluation error: %w", err) //nolint:stylecheck
 		}
 
-		pp.Println(result.Inspect())
+		switch stmt.(type) {
+		case *ast.LetStatement, *ast.ImportStatement:
+			r.definitions = append(r.definitions, source[stmt.Pos().Offset:stmt.End().Offset])
+		case *ast.ReturnStatement:
+			return result, nil
+		}
 	}
+
+	return result, nil
+}
+
+func (r *Repl) printf(format string, args ...any) {
+	fmt.Fprintf(r.out, format, args...) //nolint:errcheck
+}
+
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("config dir error: %w", err)
+	}
+
+	dir = filepath.Join(dir, "monkey")
+
+	err = os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return "", fmt.Errorf("config dir error: %w", err)
+	}
+
+	return dir, nil
+}
+
+func parse(source string) (*ast.Program, error) {
+	program, err := parser.New(lexer.New(source)).ParseProgram()
+	if err != nil {
+		return nil, fmt.Errorf("Parsing error: %w", err) //nolint:stylecheck
+	}
+
+	return program, nil
 }
@@ -0,0 +1,221 @@
+package repl_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing/fstest"
+
+	"github.com/k0kubun/pp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zhulik/monkey/repl"
+)
+
+var _ = Describe("Repl", func() {
+	var (
+		out  *bytes.Buffer
+		rpl  *repl.Repl
+		exec func(inputs ...string) string
+	)
+
+	BeforeEach(func() {
+		pp.ColoringEnabled = false
+
+		out = &bytes.Buffer{}
+		rpl = repl.New(out)
+
+		exec = func(inputs ...string) string {
+			for _, input := range inputs {
+				rpl.Execute(input)
+			}
+
+			return out.String()
+		}
+	})
+
+	Describe(".Execute", func() {
+		Context("when input is code", func() {
+			It("prints the result", func() {
+				Expect(exec("let a = 2;", "a * 2")).To(Equal("\"2\"\n\"4\"\n"))
+			})
+		})
+
+		Context("when input cannot be parsed", func() {
+			It("prints the error", func() {
+				Expect(exec("let 1")).To(HavePrefix("Parsing error: "))
+			})
+		})
+
+		Context("when evaluation fails", func() {
+			It("prints the error", func() {
+				Expect(exec("1 / 0")).To(Equal("Evaluation error: division by zero\n"))
+			})
+		})
+
+		Context("when input imports a module", func() {
+			It("imports from the filesystem", func() {
+				rpl = repl.New(out, repl.WithFS(fstest.MapFS{"m.mk": {Data: []byte("export let a = 1")}}))
+
+				Expect(exec(`import "m"`, "m.a")).To(Equal("\"nil\"\n\"1\"\n"))
+			})
+
+			It("fails without a filesystem", func() {
+				Expect(exec(`import "m"`)).To(Equal("Evaluation error: imports are not enabled\n"))
+			})
+
+			It("imports the standard library without a filesystem", func() {
+				Expect(exec(`import "strings"`, `strings.len("é")`)).To(Equal("\"nil\"\n\"1\"\n"))
+			})
+		})
+
+		Context("when command is unknown", func() {
+			It("prints the error", func() {
+				Expect(exec(":foo")).To(Equal("Unknown command :foo, type :help for the list of commands\n"))
+			})
+		})
+
+		Describe(":tokens", func() {
+			It("prints lexer output", func() {
+				Expect(exec(":tokens let a = 1;")).To(Equal("let\nIDENTIFIER(a)\n=\nINTEGER(1)\n;\n"))
+			})
+		})
+
+		Describe(":ast", func() {
+			It("prints the syntax tree", func() {
+				Expect(exec(":ast let f = fn(x) { if (x > 1) { x } }")).To(Equal(`Program
+  LetStatement
+    Name: IdentifierExpression f
+    Value: FunctionExpression
+      Argument: IdentifierExpression x
+      Body: BlockStatement
+        ExpressionStatement
+          IfExpression
+            Condition: InfixExpression >
+              Left: IdentifierExpression x
+              Right: IntegerExpression 1
+            Then: BlockStatement
+              ExpressionStatement
+                IdentifierExpression x
+`))
+			})
+
+			It("prints imports, exports and member access", func() {
+				Expect(exec(`:ast import { a } from "m"; export let b = c.d;`)).To(Equal(`Program
+  ImportStatement
+    Name: IdentifierExpression a
+    Path: StringExpression "m"
+  ExportStatement
+    LetStatement
+      Name: IdentifierExpression b
+      Value: MemberExpression
+        Object: IdentifierExpression c
+        Name: IdentifierExpression d
+`))
+			})
+
+			It("prints arrays and indexes", func() {
+				Expect(exec(`:ast [1, f(x)][0]`)).To(Equal(`Program
+  ExpressionStatement
+    IndexExpression
+      Left: ArrayExpression
+        IntegerExpression 1
+        CallExpression
+          Function: IdentifierExpression f
+          Argument: IdentifierExpression x
+      Index: IntegerExpression 0
+`))
+			})
+
+			It("prints pairs of hashes", func() {
+				Expect(exec(`:ast {"a": 1, b: [2]}`)).To(Equal(`Program
+  ExpressionStatement
+    HashExpression
+      Key: StringExpression "a"
+      Value: IntegerExpression 1
+      Key: IdentifierExpression b
+      Value: ArrayExpression
+        IntegerExpression 2
+`))
+			})
+		})
+
+		Describe(":env", func() {
+			It("lists session bindings", func() {
+				Expect(exec("let b = true;", "let a = 1;", ":env")).To(HaveSuffix("a: Integer = 1\nb: Boolean = true\n"))
+			})
+		})
+
+		Describe(":type", func() {
+			It("prints the type of the result", func() {
+				Expect(exec(`:type "foo"`)).To(Equal("String\n"))
+			})
+		})
+
+		Describe(":load", func() {
+			It("evaluates the file in the session environment", func() {
+				path := filepath.Join(GinkgoT().TempDir(), "file.mk")
+				Expect(os.WriteFile(path, []byte("let a = 40;\nlet b = a + 2;\n"), 0o600)).To(Succeed())
+
+				Expect(exec(":load "+path, "b")).To(Equal("42\n\"42\"\n"))
+			})
+
+			Context("when file does not exist", func() {
+				It("prints the error", func() {
+					Expect(exec(":load missing.mk")).To(HavePrefix("Loading error: "))
+				})
+			})
+		})
+
+		Describe(":reset", func() {
+			It("clears the session environment", func() {
+				Expect(exec("let a = 1;", ":reset", ":env", "a")).To(HaveSuffix("Evaluation error: identifier is unknown: a\n"))
+			})
+		})
+
+		Describe(":save and :restore", func() {
+			It("restores definitions of the saved session", func() {
+				path := filepath.Join(GinkgoT().TempDir(), "session.mk")
+
+				exec("let a = 1; a + 1", "let add = fn(x, y) {\n  x + y\n}", "let b = missing;", "let a = add(a, 1)")
+				exec(":save " + path)
+
+				Expect(os.ReadFile(path)).To(Equal([]byte("let a = 1;\nlet add = fn(x, y) {\n  x + y\n};\nlet a = add(a, 1);\n")))
+
+				restored := &bytes.Buffer{}
+				rpl = repl.New(restored)
+				rpl.Execute(":restore " + path)
+				rpl.Execute("a")
+
+				Expect(restored.String()).To(Equal("Restored session from " + path + "\n\"2\"\n"))
+			})
+
+			It("uses the session file by default", func() {
+				path := filepath.Join(GinkgoT().TempDir(), "session.mk")
+				rpl = repl.New(out, repl.WithSessionFile(path))
+
+				Expect(exec("let a = 1;", ":save", ":reset", ":restore", "a")).To(HaveSuffix("\"1\"\n"))
+			})
+
+			Context("when there is no session file", func() {
+				It("requires a file name", func() {
+					Expect(exec(":save")).To(Equal("missing argument: file name\n"))
+				})
+			})
+		})
+
+		Describe(":time", func() {
+			It("prints the result and the elapsed time", func() {
+				Expect(exec(":time 1 + 1")).To(MatchRegexp(`^2\nTime: .+s\n$`))
+			})
+		})
+	})
+
+	Describe(".Incomplete", func() {
+		It("checks the argument of commands which take code", func() {
+			Expect(rpl.Incomplete(":ast fn(x) {")).To(BeTrue())
+			Expect(rpl.Incomplete(":load (")).To(BeFalse())
+			Expect(rpl.Incomplete("fn(x) {")).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,101 @@
+package repl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/ast"
+)
+
+// printTree prints the node and its children, one node per line, indented by depth.
+func printTree(out io.Writer, node ast.Node) {
+	ast.Walk(node, treePrinter{out: out})
+}
+
+// treePrinter prints nodes visited by ast.Walk, so every node type is printed with its children.
+type treePrinter struct {
+	out    io.Writer
+	parent ast.Node
+	depth  int
+}
+
+func (p treePrinter) Visit(node ast.Node) ast.Visitor {
+	if node == nil {
+		return nil
+	}
+
+	name := strings.TrimPrefix(fmt.Sprintf("%T", node), "*ast.")
+	if label := label(p.parent, node); label != "" {
+		name = label + ": " + name
+	}
+
+	fmt.Fprintf(p.out, "%s%s%s\n", strings.Repeat("  ", p.depth), name, details(node)) //nolint:errcheck
+
+	return treePrinter{out: p.out, parent: node, depth: p.depth + 1}
+}
+
+// label names the role of the child in the parent, children of lists such as statements of a block have none.
+func label(parent, child ast.Node) string { //nolint:cyclop,funlen
+	switch parent := parent.(type) {
+	case *ast.LetStatement:
+		return lo.Ternary(child == parent.Name, "Name", "Value")
+	case *ast.InfixExpression:
+		return lo.Ternary(child == parent.V, "Left", "Right")
+	case *ast.IfExpression:
+		switch child {
+		case parent.V:
+			return "Condition"
+		case parent.Then:
+			return "Then"
+		default:
+			return "Else"
+		}
+	case *ast.TryExpression:
+		switch child {
+		case parent.V:
+			return "Try"
+		case parent.Param:
+			return "Param"
+		case parent.Catch:
+			return "Catch"
+		default:
+			return "Finally"
+		}
+	case *ast.FunctionExpression:
+		return lo.Ternary(child == parent.V, "Body", "Argument")
+	case *ast.CallExpression:
+		return lo.Ternary(child == parent.V, "Function", "Argument")
+	case *ast.MemberExpression:
+		return lo.Ternary(child == parent.V, "Object", "Name")
+	case *ast.IndexExpression:
+		return lo.Ternary(child == parent.V, "Left", "Index")
+	case *ast.HashExpression:
+		for _, pair := range parent.V {
+			if child == pair.Key {
+				return "Key"
+			}
+		}
+
+		return "Value"
+	case *ast.ImportStatement:
+		return lo.Ternary(child == parent.V, "Path", "Name")
+	default:
+		return ""
+	}
+}
+
+func details(node ast.Node) string {
+	switch node := node.(type) {
+	case *ast.IdentifierExpression, *ast.IntegerExpression, *ast.FloatExpression, *ast.BooleanExpression,
+		*ast.StringExpression:
+		return " " + node.String()
+	case *ast.PrefixExpression:
+		return " " + node.Operator
+	case *ast.InfixExpression:
+		return " " + node.Operator
+	default:
+		return ""
+	}
+}
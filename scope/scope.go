@@ -0,0 +1,241 @@
+// Package scope resolves identifiers of a program to the let statements and function parameters declaring them.
+package scope
+
+import "github.com/zhulik/monkey/ast"
+
+type Kind int
+
+const (
+	Let Kind = iota
+	Parameter
+	Import
+	Catch
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Parameter:
+		return "parameter"
+	case Import:
+		return "import"
+	case Catch:
+		return "catch"
+	default:
+		return "let"
+	}
+}
+
+// Binding is a single declaration of a name.
+type Binding struct {
+	Name     string
+	Kind     Kind
+	Ident    *ast.IdentifierExpression // the declaring identifier
+	Let      *ast.LetStatement         // nil for parameters, imports and caught errors
+	Import   *ast.ImportStatement      // nil unless the binding is an import
+	Exported bool
+	Scope    *Scope
+	Uses     []*ast.IdentifierExpression
+}
+
+// Scope is created for the program, for every function and for every catch block, if blocks share the scope
+// of the enclosing function.
+type Scope struct {
+	Node     ast.Node // *ast.Program, *ast.FunctionExpression or *ast.TryExpression of the catch block
+	Parent   *Scope
+	Children []*Scope
+	Bindings []*Binding
+
+	// pending are uses from nested functions which could not be resolved when they were visited,
+	// they may refer to bindings declared later since function bodies are evaluated when called.
+	pending []*ast.IdentifierExpression
+}
+
+// Lookup returns the latest binding of the name visible in the scope.
+func (s *Scope) Lookup(name string) *Binding {
+	for scope := s; scope != nil; scope = scope.Parent {
+		if binding := scope.lookupLocal(name); binding != nil {
+			return binding
+		}
+	}
+
+	return nil
+}
+
+func (s *Scope) lookupLocal(name string) *Binding {
+	for i := len(s.Bindings) - 1; i >= 0; i-- {
+		if s.Bindings[i].Name == name {
+			return s.Bindings[i]
+		}
+	}
+
+	return nil
+}
+
+// Info is the result of the resolution.
+type Info struct {
+	Root        *Scope
+	Definitions map[*ast.IdentifierExpression]*Binding
+	Uses        map[*ast.IdentifierExpression]*Binding
+	Unresolved  []*ast.IdentifierExpression
+}
+
+// Bindings returns all bindings of the program in declaration order.
+func (i *Info) Bindings() []*Binding {
+	result := []*Binding{}
+
+	var collect func(scope *Scope)
+	collect = func(scope *Scope) {
+		result = append(result, scope.Bindings...)
+
+		for _, child := range scope.Children {
+			collect(child)
+		}
+	}
+
+	collect(i.Root)
+
+	return result
+}
+
+// BindingOf returns the binding the identifier declares or refers to.
+func (i *Info) BindingOf(ident *ast.IdentifierExpression) *Binding {
+	if binding, ok := i.Definitions[ident]; ok {
+		return binding
+	}
+
+	return i.Uses[ident]
+}
+
+// Resolve builds scopes of the program and resolves every identifier. Like in the evaluator, a let statement's
+// value can't see the name it declares, while function bodies can see bindings declared after the function.
+func Resolve(program *ast.Program) *Info {
+	info := &Info{
+		Root:        &Scope{Node: program},
+		Definitions: map[*ast.IdentifierExpression]*Binding{},
+		Uses:        map[*ast.IdentifierExpression]*Binding{},
+	}
+
+	ast.Walk(program, &resolver{info: info, scope: info.Root})
+	info.close(info.Root)
+
+	return info
+}
+
+func (i *Info) declare(scope *Scope, ident *ast.IdentifierExpression, kind Kind, let *ast.LetStatement) {
+	binding := &Binding{Name: ident.V, Kind: kind, Ident: ident, Let: let, Scope: scope}
+
+	scope.Bindings = append(scope.Bindings, binding)
+	i.Definitions[ident] = binding
+}
+
+func (i *Info) use(scope *Scope, ident *ast.IdentifierExpression) {
+	if binding := scope.Lookup(ident.V); binding != nil {
+		i.record(binding, ident)
+
+		return
+	}
+
+	if scope.Parent == nil {
+		i.Unresolved = append(i.Unresolved, ident)
+
+		return
+	}
+
+	scope.Parent.pending = append(scope.Parent.pending, ident)
+}
+
+// close resolves the pending uses against all bindings of the finished scope, the rest are moved up.
+func (i *Info) close(scope *Scope) {
+	for _, ident := range scope.pending {
+		binding := scope.lookupLocal(ident.V)
+
+		switch {
+		case binding != nil:
+			i.record(binding, ident)
+		case scope.Parent != nil:
+			scope.Parent.pending = append(scope.Parent.pending, ident)
+		default:
+			i.Unresolved = append(i.Unresolved, ident)
+		}
+	}
+
+	scope.pending = nil
+}
+
+func (i *Info) record(binding *Binding, ident *ast.IdentifierExpression) {
+	binding.Uses = append(binding.Uses, ident)
+	i.Uses[ident] = binding
+}
+
+type resolver struct {
+	info  *Info
+	scope *Scope
+}
+
+func (r *resolver) Visit(node ast.Node) ast.Visitor {
+	switch node := node.(type) {
+	case *ast.LetStatement:
+		ast.Walk(node.V, r)
+		r.info.declare(r.scope, node.Name, Let, node)
+
+		return nil
+	case *ast.ExportStatement:
+		ast.Walk(node.V, r)
+		r.info.Definitions[node.V.Name].Exported = true
+
+		return nil
+	case *ast.ImportStatement:
+		if node.Name != nil {
+			r.info.declare(r.scope, node.Name, Import, nil)
+			r.info.Definitions[node.Name].Import = node
+		}
+
+		for _, name := range node.Names {
+			r.info.declare(r.scope, name, Import, nil)
+			r.info.Definitions[name].Import = node
+		}
+
+		return nil
+	case *ast.MemberExpression:
+		ast.Walk(node.V, r)
+
+		return nil
+	case *ast.FunctionExpression:
+		scope := &Scope{Node: node, Parent: r.scope}
+		r.scope.Children = append(r.scope.Children, scope)
+
+		for _, arg := range node.Arguments {
+			r.info.declare(scope, arg, Parameter, nil)
+		}
+
+		ast.Walk(node.V, &resolver{info: r.info, scope: scope})
+		r.info.close(scope)
+
+		return nil
+	case *ast.TryExpression:
+		ast.Walk(node.V, r)
+
+		if node.Catch != nil {
+			scope := &Scope{Node: node, Parent: r.scope}
+			r.scope.Children = append(r.scope.Children, scope)
+
+			r.info.declare(scope, node.Param, Catch, nil)
+			ast.Walk(node.Catch, &resolver{info: r.info, scope: scope})
+			r.info.close(scope)
+		}
+
+		if node.Finally != nil {
+			ast.Walk(node.Finally, r)
+		}
+
+		return nil
+	case *ast.IdentifierExpression:
+		r.info.use(r.scope, node)
+
+		return nil
+	case nil:
+		return nil
+	default:
+		return r
+	}
+}
@@ -0,0 +1,95 @@
+package scope_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/scope"
+)
+
+func resolve(input string) *scope.Info {
+	return scope.Resolve(lo.Must(parser.New(lexer.New(input)).ParseProgram()))
+}
+
+// describe renders every identifier as name@line:col -> declaration position, or "?" when unresolved.
+func describe(info *scope.Info) []string {
+	result := []string{}
+
+	for _, binding := range info.Bindings() {
+		for _, use := range binding.Uses {
+			result = append(result, fmt.Sprintf("%s@%s -> %s %s", use.V, use.Pos(), binding.Kind, binding.Ident.Pos()))
+		}
+	}
+
+	for _, ident := range info.Unresolved {
+		result = append(result, fmt.Sprintf("%s@%s -> ?", ident.V, ident.Pos()))
+	}
+
+	return result
+}
+
+var _ = Describe("Resolve", func() {
+	cases := map[string][]string{
+		"let a = 1; a":                            {"a@1:12 -> let 1:5"},
+		"a; let a = 1":                            {"a@1:1 -> ?"},
+		"let a = a":                               {"a@1:9 -> ?"},
+		"let a = 1; let a = a + 1; a":             {"a@1:20 -> let 1:5", "a@1:27 -> let 1:16"},
+		"fn(x) { x }":                             {"x@1:9 -> parameter 1:4"},
+		"let x = 1; fn(x) { x }; x":               {"x@1:20 -> parameter 1:15", "x@1:25 -> let 1:5"},
+		"let f = fn() { g() }; let g = 1":         {"g@1:16 -> let 1:27"},
+		"let f = fn(n) { f(n) }":                  {"n@1:19 -> parameter 1:12", "f@1:17 -> let 1:5"},
+		"if (true) { let a = 1 }; a":              {"a@1:26 -> let 1:17"},
+		"fn() { let a = 1 }; a":                   {"a@1:21 -> ?"},
+		"fn() { fn() { b } }; let b = 1":          {"b@1:15 -> let 1:26"},
+		"let y = fn(a) { fn(b) { a + b } }":       {"a@1:25 -> parameter 1:12", "b@1:29 -> parameter 1:20"},
+		`import "lib/m"; m.a`:                     {"m@1:17 -> import 1:8"},
+		`import { a, b } from "m"; a.b`:           {"a@1:27 -> import 1:10"},
+		"export let a = 1; a":                     {"a@1:19 -> let 1:12"},
+		"try { let a = 1 } catch (e) { e }; a":    {"e@1:31 -> catch 1:26", "a@1:36 -> let 1:11"},
+		"try { 1 } catch (e) { let b = 1 }; b; e": {"b@1:36 -> ?", "e@1:39 -> ?"},
+	}
+
+	for input, expected := range cases {
+		Context("when resolving "+input, func() {
+			It("resolves identifiers", func() {
+				Expect(describe(resolve(input))).To(ConsistOf(expected))
+			})
+		})
+	}
+
+	It("marks exported bindings", func() {
+		info := resolve("export let a = 1; let b = 2")
+
+		Expect(lo.Map(info.Bindings(), func(binding *scope.Binding, _ int) bool { return binding.Exported })).
+			To(Equal([]bool{true, false}))
+	})
+
+	It("returns the binding of declarations and uses", func() {
+		info := resolve("let a = 1; a")
+		program := info.Root.Node.(*ast.Program)
+
+		let := program.Statements[0].(*ast.LetStatement)
+		use := program.Statements[1].(*ast.ExpressionStatement).V.(*ast.IdentifierExpression)
+
+		Expect(info.BindingOf(let.Name)).To(Equal(info.BindingOf(use)))
+		Expect(info.BindingOf(use).Let).To(Equal(let))
+	})
+
+	It("builds nested scopes", func() {
+		info := resolve("let f = fn(a) { let b = fn(c) { c } }")
+
+		Expect(info.Root.Bindings).To(HaveLen(1))
+		Expect(info.Root.Children).To(HaveLen(1))
+
+		function := info.Root.Children[0]
+		Expect(function.Parent).To(Equal(info.Root))
+		Expect(lo.Map(function.Bindings, func(b *scope.Binding, _ int) string { return b.Name })).To(Equal([]string{"a", "b"}))
+		Expect(function.Children[0].Lookup("a").Scope).To(Equal(function))
+		Expect(function.Children[0].Lookup("f").Scope).To(Equal(info.Root))
+	})
+})
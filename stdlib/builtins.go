@@ -0,0 +1,434 @@
+package stdlib
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+// Builtins creates global functions working with arrays and the error function. Callbacks are Monkey functions
+// or builtins, their errors stop the iteration and are returned. Iterations are stopped when the evaluator's
+// context is done.
+func Builtins(e evaluator.Evaluator) map[string]obj.Object {
+	c := collections{e: e}
+
+	functions := map[string]function{
+		"map":      c.mapFn,
+		"filter":   c.filter,
+		"reduce":   c.reduce,
+		"sort":     c.sort,
+		"each":     c.each,
+		"zip":      zip,
+		"range":    c.rangeFn,
+		"any":      c.any,
+		"all":      c.all,
+		"find":     c.find,
+		"group_by": c.groupBy,
+		"error":    newError,
+	}
+
+	builtins := make(map[string]obj.Object, len(functions))
+
+	for name, fn := range functions {
+		builtins[name] = builtin(name, fn)
+	}
+
+	return builtins
+}
+
+// maxRangeLength limits lengths of arrays created by range, so scripts can't exhaust the memory of the host.
+const maxRangeLength = 1 << 24
+
+type collections struct {
+	e evaluator.Evaluator
+}
+
+// call calls the callback unless the evaluator's context is done.
+func (c collections) call(callback obj.Callable, args ...obj.Object) (obj.Object, error) {
+	err := c.e.Context().Err()
+	if err != nil {
+		return obj.NIL, err //nolint:wrapcheck
+	}
+
+	return callback.Call(args...) //nolint:wrapcheck
+}
+
+// test calls the predicate, it must return a Boolean.
+func (c collections) test(predicate obj.Callable, args ...obj.Object) (bool, error) {
+	result, err := c.call(predicate, args...)
+	if err != nil {
+		return false, err
+	}
+
+	boolean, ok := result.(obj.Boolean)
+	if !ok {
+		return false, fmt.Errorf("%w: function must return Boolean, returned %s", obj.ErrWronArgumentType, result.TypeName())
+	}
+
+	return boolean.Value(), nil
+}
+
+// arrayAndCallback returns arguments of functions called as f(array, fn).
+func arrayAndCallback(args []obj.Object) ([]obj.Object, obj.Callable, error) {
+	array, _, err := args2[obj.Array, obj.Object](args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	callback, err := callable(args, 1)
+
+	return array.Value(), callback, err
+}
+
+// callable returns the i-th argument if it can be called.
+func callable(args []obj.Object, i int) (obj.Callable, error) {
+	callback, ok := args[i].(obj.Callable)
+	if !ok {
+		return nil, fmt.Errorf("%w: argument %d must be a function, given %s",
+			obj.ErrWronArgumentType, i+1, args[i].TypeName())
+	}
+
+	return callback, nil
+}
+
+// map(array, fn) returns an Array of results of fn called with each element.
+func (c collections) mapFn(args ...obj.Object) (obj.Object, error) {
+	elements, fn, err := arrayAndCallback(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	results := make([]obj.Object, len(elements))
+
+	for i, element := range elements {
+		results[i], err = c.call(fn, element)
+		if err != nil {
+			return obj.NIL, err
+		}
+	}
+
+	return obj.New[obj.Array](results), nil
+}
+
+// filter(array, fn) returns an Array of elements for which fn returns true.
+func (c collections) filter(args ...obj.Object) (obj.Object, error) {
+	elements, fn, err := arrayAndCallback(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	results := []obj.Object{}
+
+	for _, element := range elements {
+		ok, tErr := c.test(fn, element)
+		if tErr != nil {
+			return obj.NIL, tErr
+		}
+
+		if ok {
+			results = append(results, element)
+		}
+	}
+
+	return obj.New[obj.Array](results), nil
+}
+
+// reduce(array, initial, fn) folds the array calling fn(accumulator, element), initial is the first accumulator.
+func (c collections) reduce(args ...obj.Object) (obj.Object, error) {
+	array, accumulator, _, err := args3[obj.Array, obj.Object, obj.Object](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	fn, err := callable(args, 2) //nolint:mnd
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	for _, element := range array.Value() {
+		accumulator, err = c.call(fn, accumulator, element)
+		if err != nil {
+			return obj.NIL, err
+		}
+	}
+
+	return accumulator, nil
+}
+
+// sort(array) or sort(array, fn) returns a sorted copy of the array, the sort is stable. Elements are compared
+// with the < operator unless fn(a, b) is given, it returns true if a goes before b.
+func (c collections) sort(args ...obj.Object) (obj.Object, error) {
+	var less func(a, b obj.Object) (bool, error)
+
+	if len(args) == 1 {
+		_, err := args1[obj.Array](args)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		less = func(a, b obj.Object) (bool, error) {
+			return compare(a, b, obj.OperatorLT.OperatorLT)
+		}
+	} else {
+		_, fn, err := arrayAndCallback(args)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		less = func(a, b obj.Object) (bool, error) {
+			return c.test(fn, a, b)
+		}
+	}
+
+	sorted := slices.Clone(args[0].(obj.Array).Value()) //nolint:forcetypeassert
+
+	// The first error is kept, the sort can't be interrupted so remaining comparisons are skipped.
+	var err error
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+
+		var before bool
+
+		before, err = less(sorted[i], sorted[j])
+
+		return before
+	})
+
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.Array](sorted), nil
+}
+
+// each(array, fn) calls fn with each element, it returns nil.
+func (c collections) each(args ...obj.Object) (obj.Object, error) {
+	elements, fn, err := arrayAndCallback(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	for _, element := range elements {
+		_, err = c.call(fn, element)
+		if err != nil {
+			return obj.NIL, err
+		}
+	}
+
+	return obj.NIL, nil
+}
+
+// zip(array, ...) returns an Array of Arrays of elements with the same index, its length is the shortest length.
+func zip(args ...obj.Object) (obj.Object, error) {
+	if len(args) == 0 {
+		return obj.NIL, fmt.Errorf("%w: expects at least 1, given 0", obj.ErrWrongNumberOfArguments)
+	}
+
+	arrays := make([][]obj.Object, len(args))
+
+	for i := range args {
+		array, err := argument[obj.Array](args, i)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		arrays[i] = array.Value()
+	}
+
+	length := len(slices.MinFunc(arrays, func(a, b []obj.Object) int { return len(a) - len(b) }))
+	results := make([]obj.Object, length)
+
+	for i := range results {
+		tuple := make([]obj.Object, len(arrays))
+
+		for j, array := range arrays {
+			tuple[j] = array[i]
+		}
+
+		results[i] = obj.New[obj.Array](tuple)
+	}
+
+	return obj.New[obj.Array](results), nil
+}
+
+// range(end), range(start, end) or range(start, end, step) returns an Array of Integers from start up to,
+// but not including, end. Ranges longer than maxRangeLength are rejected.
+func (c collections) rangeFn(args ...obj.Object) (obj.Object, error) {
+	start, end, step := obj.New[obj.Integer](0), obj.New[obj.Integer](0), obj.New[obj.Integer](1)
+
+	var err error
+
+	switch len(args) {
+	case 0, 1:
+		end, err = args1[obj.Integer](args)
+	case 2: //nolint:mnd
+		start, end, err = args2[obj.Integer, obj.Integer](args)
+	default:
+		start, end, step, err = args3[obj.Integer, obj.Integer, obj.Integer](args)
+	}
+
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	if step.Value() == 0 {
+		return obj.NIL, fmt.Errorf("%w: zero step", ErrInvalidArgument)
+	}
+
+	length := rangeLength(start.Value(), end.Value(), step.Value())
+	if length > maxRangeLength {
+		return obj.NIL, fmt.Errorf("%w: range of %d elements is longer than %d", ErrInvalidArgument, length, maxRangeLength)
+	}
+
+	results := make([]obj.Object, length)
+
+	for i := range results {
+		err = c.e.Context().Err()
+		if err != nil {
+			return obj.NIL, err //nolint:wrapcheck
+		}
+
+		results[i] = obj.New[obj.Integer](start.Value() + int64(i)*step.Value())
+	}
+
+	return obj.New[obj.Array](results), nil
+}
+
+// rangeLength returns the number of elements of the range, the distance is computed in uint64 so it can't overflow.
+func rangeLength(start, end, step int64) uint64 {
+	var distance, stride uint64
+
+	switch {
+	case step > 0 && start < end:
+		distance, stride = uint64(end)-uint64(start), uint64(step)
+	case step < 0 && start > end:
+		distance, stride = uint64(start)-uint64(end), -uint64(step)
+	default:
+		return 0
+	}
+
+	return (distance-1)/stride + 1
+}
+
+// any(array, fn) reports whether fn returns true for at least one element.
+func (c collections) any(args ...obj.Object) (obj.Object, error) {
+	elements, fn, err := arrayAndCallback(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	for _, element := range elements {
+		ok, tErr := c.test(fn, element)
+		if tErr != nil || ok {
+			return obj.ToBoolean(ok), tErr
+		}
+	}
+
+	return obj.FALSE, nil
+}
+
+// all(array, fn) reports whether fn returns true for every element.
+func (c collections) all(args ...obj.Object) (obj.Object, error) {
+	elements, fn, err := arrayAndCallback(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	for _, element := range elements {
+		ok, tErr := c.test(fn, element)
+		if tErr != nil || !ok {
+			return obj.ToBoolean(ok), tErr
+		}
+	}
+
+	return obj.TRUE, nil
+}
+
+// find(array, fn) returns the first element for which fn returns true, or nil.
+func (c collections) find(args ...obj.Object) (obj.Object, error) {
+	elements, fn, err := arrayAndCallback(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	for _, element := range elements {
+		ok, tErr := c.test(fn, element)
+		if tErr != nil {
+			return obj.NIL, tErr
+		}
+
+		if ok {
+			return element, nil
+		}
+	}
+
+	return obj.NIL, nil
+}
+
+// group_by(array, fn) returns a Hash of Arrays of elements with equal results of fn, keys are in the order
+// of first occurrence.
+func (c collections) groupBy(args ...obj.Object) (obj.Object, error) {
+	elements, fn, err := arrayAndCallback(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	keys := []obj.Object{}
+	groups := map[obj.HashKey][]obj.Object{}
+
+	for _, element := range elements {
+		key, cErr := c.call(fn, element)
+		if cErr != nil {
+			return obj.NIL, cErr
+		}
+
+		hashable, ok := key.(obj.Hashable)
+		if !ok {
+			return obj.NIL, fmt.Errorf("%w: %s", obj.ErrUnhashable, key.TypeName())
+		}
+
+		hashKey := hashable.HashKey()
+		if _, ok := groups[hashKey]; !ok {
+			keys = append(keys, key)
+		}
+
+		groups[hashKey] = append(groups[hashKey], element)
+	}
+
+	pairs := make([]obj.HashPair, len(keys))
+
+	for i, key := range keys {
+		pairs[i] = obj.HashPair{Key: key, Value: obj.New[obj.Array](groups[key.(obj.Hashable).HashKey()])} //nolint:forcetypeassert
+	}
+
+	return obj.NewHash(pairs...) //nolint:wrapcheck
+}
+
+// error(message) or error(kind, message) returns an Error to be thrown, the kind is Error unless given.
+func newError(args ...obj.Object) (obj.Object, error) {
+	if len(args) == 1 {
+		message, err := args1[obj.String](args)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		return obj.Error{Kind: "Error", Message: message.Value()}, nil
+	}
+
+	kind, message, err := args2[obj.String, obj.String](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	if kind.Value() == "" {
+		return obj.NIL, fmt.Errorf("%w: empty kind", ErrInvalidArgument)
+	}
+
+	return obj.Error{Kind: kind.Value(), Message: message.Value()}, nil
+}
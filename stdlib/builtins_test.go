@@ -0,0 +1,109 @@
+package stdlib_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+	"github.com/zhulik/monkey/stdlib"
+)
+
+var _ = Describe("Builtins", func() {
+	evalBuiltins := func(source string, opts ...evaluator.Option) (string, error) {
+		result, err := eval(source, opts...)
+		if err != nil {
+			return "", err
+		}
+
+		return result.Inspect(), nil
+	}
+
+	It("names builtins", func() {
+		Expect(evaluator.New(stdlib.Options()...).Builtins()).To(Equal([]string{
+			"all", "any", "each", "error", "filter", "find", "group_by", "map", "range", "reduce", "sort", "zip",
+		}))
+	})
+
+	Context("when arguments are valid", func() {
+		cases := map[string]string{
+			`map([1, 2, 3], fn(x) { x * 2 })`:                                        "[2, 4, 6]",
+			`map(["a", "b"], strings.upper)`:                                         `["A", "B"]`,
+			`filter(range(10), fn(x) { x / 2 * 2 == x })`:                            "[0, 2, 4, 6, 8]",
+			`reduce([1, 2, 3], 10, fn(acc, x) { acc + x })`:                          "16",
+			`reduce([], "empty", fn(acc, x) { x })`:                                  `"empty"`,
+			`sort([3, 1.5, 2])`:                                                      "[1.5, 2, 3]",
+			`sort([2, 1, 3], fn(a, b) { b < a })`:                                    "[3, 2, 1]",
+			`sort([[2, "a"], [1, "b"], [2, "c"]], fn(a, b) { a[0] < b[0] })`:         `[[1, "b"], [2, "a"], [2, "c"]]`,
+			`let a = [2, 1]; sort(a); a`:                                             "[2, 1]",
+			`each([1, 2], fn(x) { x })`:                                              "nil",
+			`zip([1, 2, 3], ["a", "b"])`:                                             `[[1, "a"], [2, "b"]]`,
+			`[range(3), range(1, 3), range(5, 0, -2), range(0)]`:                     "[[0, 1, 2], [1, 2], [5, 3, 1], []]",
+			`range(9223372036854775806, 9223372036854775807, 4611686018427387904)`:   "[9223372036854775806]",
+			`range(-9223372036854775807, 9223372036854775807, 9223372036854775807)`:  "[-9223372036854775807, 0]",
+			`range(9223372036854775807, -9223372036854775807, -9223372036854775807)`: "[9223372036854775807, 0]",
+			`[any([1, 2], fn(x) { x > 1 }), any([], fn(x) { true })]`:                "[true, false]",
+			`[all([1, 2], fn(x) { x > 1 }), all([], fn(x) { false })]`:               "[false, true]",
+			`[find([1, 2, 3], fn(x) { x > 1 }), find([1], fn(x) { false })]`:         "[2, nil]",
+			`group_by(["ab", "c", "de"], strings.len)`:                               `{2: ["ab", "de"], 1: ["c"]}`,
+			`let map = fn(x) { x }; map(1)`:                                          "1",
+			`error("boom")`:                                                          "Error: boom",
+			`try { throw error("Timeout", "slow") } catch (e) { e }`:                 "Timeout: slow",
+			`try { map([1, 0], fn(x) { 1 / x }) } catch (e) { e }`:                   "DivisionByZero: division by zero",
+			`try { range(0, 1, 0) } catch (e) { e }`:                                 "InvalidArgument: range: invalid argument: zero step",
+			`try { json.parse("[") } catch (e) { e.kind }`:                           `"InvalidJSON"`,
+		}
+
+		for source, expected := range cases {
+			It("returns "+expected+" for "+source, func() {
+				Expect(evalBuiltins(source)).To(Equal(expected))
+			})
+		}
+	})
+
+	It("propagates errors of callbacks", func() {
+		_, err := evalBuiltins(`map([1, 0], fn(x) { 1 / x })`)
+		Expect(err).To(MatchError(obj.ErrDevisionByZero))
+		Expect(err).To(MatchError("map: division by zero"))
+	})
+
+	It("stops when the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := evalBuiltins(`each([1], strings.upper)`, evaluator.WithContext(ctx))
+		Expect(err).To(MatchError(context.Canceled))
+
+		_, err = evalBuiltins(`range(1000000)`, evaluator.WithContext(ctx))
+		Expect(err).To(MatchError(context.Canceled))
+	})
+
+	Context("when arguments are invalid", func() {
+		cases := map[string]string{
+			`map([1], 1)`:                    "map: argument type is wrong: argument 2 must be a function, given Integer",
+			`map(1, fn(x) { x })`:            "map: argument type is wrong: argument 1 must be Array, given Integer",
+			`map([1], fn(a, b) { a })`:       "map: wrong number of arguments: <anonymous> expects 2, given 1",
+			`filter([1], fn(x) { x })`:       "filter: argument type is wrong: function must return Boolean, returned Integer",
+			`sort([1, "a"])`:                 "sort: method is not defined: String can't be compared",
+			`sort([nil, nil])`:               "sort: method is not defined: Nil can't be compared",
+			`sort([1, 2], fn(a, b) { nil })`: "sort: argument type is wrong: function must return Boolean, returned Nil",
+			`reduce([1], fn(a, x) { a })`:    "reduce: wrong number of arguments: expects 3, given 2",
+			`zip()`:                          "zip: wrong number of arguments: expects at least 1, given 0",
+			`zip([1], 2)`:                    "zip: argument type is wrong: argument 2 must be Array, given Integer",
+			`range(0, 10, 0)`:                "range: invalid argument: zero step",
+			`range(20000000)`:                "range: invalid argument: range of 20000000 elements is longer than 16777216",
+			`range(1.5)`:                     "range: argument type is wrong: argument 1 must be Integer, given Float",
+			`group_by([1], fn(x) { [x] })`:   "group_by: object can't be used as a hash key: Array",
+			`error(1)`:                       "error: argument type is wrong: argument 1 must be String, given Integer",
+			`error("", "a")`:                 "error: invalid argument: empty kind",
+		}
+
+		for source, message := range cases {
+			It("fails on "+source, func() {
+				_, err := evalBuiltins(source)
+				Expect(err).To(MatchError(message))
+			})
+		}
+	})
+})
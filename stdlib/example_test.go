@@ -0,0 +1,275 @@
+package stdlib_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/stdlib"
+)
+
+// run evaluates the source with the standard library and prints the result or the error.
+func run(source string, opts ...evaluator.Option) {
+	program, err := parser.New(lexer.New(source)).ParseProgram()
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	result, err := evaluator.New(append(stdlib.Options(), opts...)...).Eval(program)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Println(result.Inspect())
+}
+
+func ExampleStrings() {
+	run(`
+		import { split, join, upper } from "strings";
+
+		join(split(upper("a-b-c"), "-"), ", ")
+	`)
+	// Output: "A, B, C"
+}
+
+func ExampleStrings_split() {
+	run(`import "strings"; strings.split("a,b,c", ",")`)
+	run(`import "strings"; strings.split("héé", "")`)
+	// Output:
+	// ["a", "b", "c"]
+	// ["h", "é", "é"]
+}
+
+func ExampleStrings_join() {
+	run(`import "strings"; strings.join(["a", "b", "c"], "-")`)
+	// Output: "a-b-c"
+}
+
+func ExampleStrings_trim() {
+	run(`import "strings"; strings.trim("  a b  ")`)
+	run(`import "strings"; strings.trim_left("  a ")`)
+	run(`import "strings"; strings.trim_right("  a ")`)
+	// Output:
+	// "a b"
+	// "a "
+	// "  a"
+}
+
+func ExampleStrings_replace() {
+	run(`import "strings"; strings.replace("a-b-c", "-", "+")`)
+	// Output: "a+b+c"
+}
+
+func ExampleStrings_contains() {
+	run(`import "strings"; strings.contains("monkey", "key")`)
+	run(`import "strings"; strings.starts_with("monkey", "mon")`)
+	run(`import "strings"; strings.ends_with("monkey", "mon")`)
+	// Output:
+	// true
+	// true
+	// false
+}
+
+func ExampleStrings_index() {
+	run(`import "strings"; strings.index("héllo", "l")`)
+	run(`import "strings"; strings.index("héllo", "x")`)
+	// Output:
+	// 2
+	// -1
+}
+
+func ExampleStrings_upper() {
+	run(`import "strings"; strings.upper("straße")`)
+	run(`import "strings"; strings.lower("ÀB")`)
+	// Output:
+	// "STRAßE"
+	// "àb"
+}
+
+func ExampleStrings_repeat() {
+	run(`import "strings"; strings.repeat("ab", 3)`)
+	// Output: "ababab"
+}
+
+func ExampleStrings_pad() {
+	run(`import "strings"; strings.pad_left("7", 3, "0")`)
+	run(`import "strings"; strings.pad_right("é", 4, "-=")`)
+	run(`import "strings"; strings.pad_left("long", 2, " ")`)
+	// Output:
+	// "007"
+	// "é-=-"
+	// "long"
+}
+
+func ExampleStrings_len() {
+	run(`import "strings"; strings.len("héllo")`)
+	// Output: 5
+}
+
+func ExampleStrings_slice() {
+	run(`import "strings"; strings.slice("héllo", 1, 3)`)
+	// Output: "él"
+}
+
+func ExampleMath() {
+	run(`
+		import "math";
+
+		let hypot = fn(a, b) { math.sqrt(a * a + b * b) };
+
+		[hypot(3, 4), math.round(math.PI * 100), math.max(1, 2.5, 2)]
+	`)
+	// Output: [5.0, 314, 2.5]
+}
+
+func ExampleMath_abs() {
+	run(`import "math"; [math.abs(-2), math.abs(-2.5), math.abs(3)]`)
+	// Output: [2, 2.5, 3]
+}
+
+func ExampleMath_min() {
+	run(`import "math"; [math.min(3, 1, 2), math.max(3, 1, 2), math.min(1, 0.5)]`)
+	// Output: [1, 3, 0.5]
+}
+
+func ExampleMath_clamp() {
+	run(`import "math"; [math.clamp(15, 0, 10), math.clamp(-1, 0, 10), math.clamp(0.5, 0, 1)]`)
+	// Output: [10, 0, 0.5]
+}
+
+func ExampleMath_pow() {
+	run(`import "math"; [math.pow(2, 10), math.pow(2, -1), math.pow(4, 0.5)]`)
+	// Output: [1024, 0.5, 2.0]
+}
+
+func ExampleMath_sqrt() {
+	run(`import "math"; [math.sqrt(16), math.exp(0), math.log(math.E), math.log2(8), math.log10(1000)]`)
+	// Output: [4.0, 1.0, 1.0, 3.0, 3.0]
+}
+
+func ExampleMath_sin() {
+	run(`import "math"; [math.sin(0), math.cos(0), math.round(math.atan2(1, 1) * 4 * 1000)]`)
+	// Output: [0.0, 1.0, 3142]
+}
+
+func ExampleMath_floor() {
+	run(`import "math"; [math.floor(1.5), math.ceil(1.5), math.round(1.5), math.round(-1.5), math.floor(7)]`)
+	// Output: [1, 2, 2, -2, 7]
+}
+
+func ExampleMath_random() {
+	seeded := evaluator.WithRandSource(rand.NewPCG(1, 2))
+
+	run(`import "math"; [math.random(100), math.random(100), math.random() < 1]`, seeded)
+	// Output: [76, 61, true]
+}
+
+func ExampleJSON() {
+	// Hosts provide payloads to scripts through modules.
+	webhook := evaluator.WithModule("webhook", func(evaluator.Evaluator) obj.Module {
+		return obj.NewModule("webhook", map[string]obj.Object{
+			"payload": obj.New[obj.String](`{"name": "monkey", "tags": ["lang", null], "version": 1.5}`),
+		})
+	})
+
+	run(`
+		import "json";
+		import { payload } from "webhook";
+
+		let config = json.parse(payload);
+
+		[config["name"], config["tags"], config["version"], config["missing"]]
+	`, webhook)
+	// Output: ["monkey", ["lang", nil], 1.5, nil]
+}
+
+func ExampleJSON_stringify() {
+	run(`import "json"; json.stringify({"a": [1, 2.5, true], "b": nil, "c": "<x>"})`)
+	// Output: "{"a":[1,2.5,true],"b":null,"c":"<x>"}"
+}
+
+func ExampleJSON_stringify_indent() {
+	run(`import "json"; json.stringify({"a": [1, 2]}, 2)`)
+	// Output:
+	// "{
+	//   "a": [
+	//     1,
+	//     2
+	//   ]
+	// }"
+}
+
+func ExampleJSON_parse_error() {
+	run(`import "json"; json.parse("[1, 2,]")`)
+	// Output: json.parse: invalid JSON at offset 7: invalid character ']' looking for beginning of value
+}
+
+func ExampleFS() {
+	run(`import "fs"; fs.read_file("/etc/passwd")`)
+	// Output: fs.read_file: permission denied: file access is not granted
+}
+
+func ExampleTime() {
+	run(`
+		import "time";
+
+		let start = time.parse("2024-03-01T09:00:00Z");
+		let end = start + time.HOUR * 2 + time.MINUTE * 15;
+
+		[time.format(time.in_zone(end, "Europe/Berlin"), time.DATE_TIME), end - start, end > start]
+	`)
+	// Output: ["2024-03-01 12:15:00", 2h15m0s, true]
+}
+
+func ExampleRegex() {
+	run(`
+		import "regex";
+
+		let line = "user=monkey id=42";
+		let pair = regex.compile("(?P<key>\w+)=(?P<value>\w+)");
+
+		[line =~ pair, regex.find(pair, line)["value"], regex.replace(pair, line, fn(m) { m["key"] })]
+	`)
+	// Output: [true, "monkey", "user id"]
+}
+
+func ExampleBuiltins() {
+	run(`
+		let orders = [{"user": "a", "total": 10}, {"user": "b", "total": 25}, {"user": "a", "total": 5}];
+		let total = fn(order) { order["total"] };
+
+		let big = filter(orders, fn(order) { total(order) > 7 });
+		let users = group_by(orders, fn(order) { order["user"] });
+
+		[reduce(map(orders, total), 0, fn(sum, x) { sum + x }), sort(map(big, total)), map(users["a"], total)]
+	`)
+	// Output: [40, [10, 25], [10, 5]]
+}
+
+func ExampleBuiltins_error() {
+	run(`
+		import "json";
+
+		let parse = fn(payload) {
+			try {
+				json.parse(payload)
+			} catch (e) {
+				if (e.kind == "InvalidJSON") {
+					throw error("BadRequest", e.message);
+				}
+
+				throw e;
+			}
+		};
+
+		try { parse("{") } catch (e) { [e.kind, e.message, e.stack] } finally { "cleaned up" }
+	`)
+	// Output: ["BadRequest", "json.parse: invalid JSON at offset 1: unexpected end of JSON input", ["main 16:9", "parse 9:6"]]
+}
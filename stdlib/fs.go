@@ -0,0 +1,187 @@
+package stdlib
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+const writeFileMode = 0o644
+
+// FS creates the fs module. Files are only accessible in directories granted with evaluator.WithFileRoots,
+// relative paths are resolved against the first granted directory. Symbolic links are resolved when the path is
+// checked and the file is opened afterwards, so the sandbox doesn't guard against links changed in between:
+// grant only directories other processes can't write to.
+func FS(e evaluator.Evaluator) obj.Module {
+	s := sandbox{roots: lo.Map(e.FileRoots(), func(root string, _ int) string {
+		abs, err := filepath.Abs(root)
+		if err != nil {
+			return filepath.Clean(root)
+		}
+
+		return realPath(abs)
+	})}
+
+	return newModule("fs", map[string]function{
+		"read_file":  s.readFile,
+		"write_file": s.writeFile,
+		"list_dir":   s.listDir,
+		"exists":     s.exists,
+		"stat":       s.stat,
+	}, nil)
+}
+
+type sandbox struct {
+	roots []string
+}
+
+// resolve returns the resolved path of the path argument if it's inside of a granted directory.
+func (s sandbox) resolve(path obj.String) (string, error) {
+	if len(s.roots) == 0 {
+		return "", fmt.Errorf("%w: file access is not granted", ErrPermissionDenied)
+	}
+
+	name := path.Value()
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(s.roots[0], name)
+	}
+
+	resolved := realPath(filepath.Clean(name))
+
+	// realPath keeps dangling links, writing to them would create their targets wherever they are.
+	if info, err := os.Lstat(resolved); err == nil && info.Mode()&fs.ModeSymlink != 0 {
+		return "", fmt.Errorf("%w: %s is a dangling link", ErrPermissionDenied, path.Value())
+	}
+
+	for _, root := range s.roots {
+		rel, err := filepath.Rel(root, resolved)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return resolved, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s is outside of granted directories", ErrPermissionDenied, path.Value())
+}
+
+// read_file(path) returns the content of the file.
+func (s sandbox) readFile(args ...obj.Object) (obj.Object, error) {
+	path, err := s.pathArg(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return obj.NIL, err //nolint:wrapcheck
+	}
+
+	return obj.New[obj.String](string(content)), nil
+}
+
+// write_file(path, content) creates or truncates the file and writes the content to it.
+func (s sandbox) writeFile(args ...obj.Object) (obj.Object, error) {
+	name, content, err := args2[obj.String, obj.String](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	path, err := s.resolve(name)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.NIL, os.WriteFile(path, []byte(content.Value()), writeFileMode) //nolint:wrapcheck
+}
+
+// list_dir(path) returns names of entries of the directory sorted by name.
+func (s sandbox) listDir(args ...obj.Object) (obj.Object, error) {
+	path, err := s.pathArg(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return obj.NIL, err //nolint:wrapcheck
+	}
+
+	names := lo.Map(entries, func(entry fs.DirEntry, _ int) obj.Object {
+		return obj.New[obj.String](entry.Name())
+	})
+
+	return obj.New[obj.Array](names), nil
+}
+
+// exists(path) reports whether the file or directory exists.
+func (s sandbox) exists(args ...obj.Object) (obj.Object, error) {
+	path, err := s.pathArg(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	_, err = os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return obj.FALSE, nil
+	}
+
+	if err != nil {
+		return obj.NIL, err //nolint:wrapcheck
+	}
+
+	return obj.TRUE, nil
+}
+
+// stat(path) returns a Hash describing the file: name, size in bytes, dir, mode and modified as Unix seconds.
+func (s sandbox) stat(args ...obj.Object) (obj.Object, error) {
+	path, err := s.pathArg(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return obj.NIL, err //nolint:wrapcheck
+	}
+
+	return obj.NewHash( //nolint:wrapcheck
+		obj.HashPair{Key: obj.New[obj.String]("name"), Value: obj.New[obj.String](info.Name())},
+		obj.HashPair{Key: obj.New[obj.String]("size"), Value: obj.New[obj.Integer](info.Size())},
+		obj.HashPair{Key: obj.New[obj.String]("dir"), Value: obj.ToBoolean(info.IsDir())},
+		obj.HashPair{Key: obj.New[obj.String]("mode"), Value: obj.New[obj.String](info.Mode().String())},
+		obj.HashPair{Key: obj.New[obj.String]("modified"), Value: obj.New[obj.Integer](info.ModTime().Unix())},
+	)
+}
+
+// pathArg resolves the only path argument.
+func (s sandbox) pathArg(args []obj.Object) (string, error) {
+	path, err := args1[obj.String](args)
+	if err != nil {
+		return "", err
+	}
+
+	return s.resolve(path)
+}
+
+// realPath evaluates symbolic links of the absolute path, missing trailing elements are kept as is.
+func realPath(path string) string {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err == nil {
+		return resolved
+	}
+
+	dir := filepath.Dir(path)
+	if dir == path {
+		return path
+	}
+
+	return filepath.Join(realPath(dir), filepath.Base(path))
+}
@@ -0,0 +1,96 @@
+package stdlib_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zhulik/monkey/evaluator"
+	"github.com/zhulik/monkey/stdlib"
+)
+
+var _ = Describe("FS", func() {
+	var root, outside string
+
+	evalFS := func(source string) (string, error) {
+		result, err := eval(`import "fs"; `+source, evaluator.WithFileRoots(root))
+		if err != nil {
+			return "", err
+		}
+
+		return result.Inspect(), nil
+	}
+
+	BeforeEach(func() {
+		dir := GinkgoT().TempDir()
+		root = filepath.Join(dir, "root")
+		outside = filepath.Join(dir, "outside")
+
+		Expect(os.MkdirAll(filepath.Join(root, "sub"), 0o755)).To(Succeed())
+		Expect(os.Mkdir(outside, 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(root, "input.txt"), []byte("hello"), 0o600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0o600)).To(Succeed())
+	})
+
+	It("reads and writes files in granted directories", func() {
+		Expect(evalFS(`fs.write_file("sub/output.txt", fs.read_file("input.txt") + "!")`)).To(Equal("nil"))
+		Expect(evalFS(`fs.read_file("` + filepath.Join(root, "sub", "output.txt") + `")`)).To(Equal(`"hello!"`))
+		Expect(evalFS(`fs.list_dir(".")`)).To(Equal(`["input.txt", "sub"]`))
+		Expect(evalFS(`[fs.exists("input.txt"), fs.exists("missing.txt")]`)).To(Equal("[true, false]"))
+		Expect(evalFS(`let s = fs.stat("input.txt"); [s["name"], s["size"], s["dir"], fs.stat("sub")["dir"]]`)).
+			To(Equal(`["input.txt", 5, false, true]`))
+	})
+
+	It("fails when files are not granted", func() {
+		_, err := eval(`import "fs"; fs.exists("input.txt")`)
+		Expect(err).To(MatchError(stdlib.ErrPermissionDenied))
+		Expect(err).To(MatchError("fs.exists: permission denied: file access is not granted"))
+	})
+
+	It("fails when the file is missing", func() {
+		_, err := evalFS(`fs.read_file("missing.txt")`)
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("names kinds of caught errors", func() {
+		Expect(evalFS(`try { fs.read_file("missing.txt") } catch (e) { e.kind }`)).To(Equal(`"NotFound"`))
+		Expect(evalFS(`try { fs.read_file("../outside/secret.txt") } catch (e) { e.kind }`)).
+			To(Equal(`"PermissionDenied"`))
+	})
+
+	Context("when paths lead outside of granted directories", func() {
+		BeforeEach(func() {
+			Expect(os.Symlink(outside, filepath.Join(root, "link"))).To(Succeed())
+			Expect(os.Symlink(filepath.Join(outside, "new.txt"), filepath.Join(root, "dangling"))).To(Succeed())
+		})
+
+		cases := map[string]string{
+			`fs.read_file("../outside/secret.txt")`: "fs.read_file: permission denied: ../outside/secret.txt is outside of granted directories",
+			`fs.read_file("sub/../../outside")`:     "fs.read_file: permission denied: sub/../../outside is outside of granted directories",
+			`fs.read_file("link/secret.txt")`:       "fs.read_file: permission denied: link/secret.txt is outside of granted directories",
+			`fs.write_file("link/new.txt", "")`:     "fs.write_file: permission denied: link/new.txt is outside of granted directories",
+			`fs.write_file("dangling", "")`:         "fs.write_file: permission denied: dangling is a dangling link",
+			`fs.list_dir("/")`:                      "fs.list_dir: permission denied: / is outside of granted directories",
+			`fs.exists("link")`:                     "fs.exists: permission denied: link is outside of granted directories",
+		}
+
+		for source, message := range cases {
+			It("fails on "+source, func() {
+				_, err := evalFS(source)
+				Expect(err).To(MatchError(message))
+
+				Expect(filepath.Join(outside, "new.txt")).ToNot(BeAnExistingFile())
+			})
+		}
+	})
+
+	It("fails on invalid arguments", func() {
+		_, err := evalFS(`fs.write_file("a.txt", 1)`)
+		Expect(err).To(MatchError("fs.write_file: argument type is wrong: argument 2 must be String, given Integer"))
+	})
+
+	It("exports functions", func() {
+		Expect(stdlib.FS(evaluator.New()).Exports).To(ConsistOf("exists", "list_dir", "read_file", "stat", "write_file"))
+	})
+})
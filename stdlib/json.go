@@ -0,0 +1,317 @@
+package stdlib
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+var (
+	ErrInvalidJSON = errors.New("invalid JSON")
+	ErrCycle       = errors.New("value contains a cycle")
+)
+
+// JSONError is returned when json.parse fails, the error was detected after reading Offset bytes of the input.
+type JSONError struct {
+	Offset int64
+	Msg    string
+}
+
+func (e *JSONError) Error() string {
+	return fmt.Sprintf("%s at offset %d: %s", ErrInvalidJSON, e.Offset, e.Msg)
+}
+
+func (e *JSONError) Unwrap() error {
+	return ErrInvalidJSON
+}
+
+// JSON creates the json module. Objects are mapped to Hashes with String keys in the document order, numbers
+// to Integers if they are integral and fit, to Floats otherwise, null to nil.
+func JSON(evaluator.Evaluator) obj.Module {
+	return newModule("json", map[string]function{
+		"parse":     parseJSON,
+		"stringify": stringifyJSON,
+	}, nil)
+}
+
+// parse(s) decodes the JSON document.
+func parseJSON(args ...obj.Object) (obj.Object, error) {
+	src, err := args1[obj.String](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	// The document is validated upfront: the token API of the decoder reports imprecise syntax errors.
+	var raw json.RawMessage
+
+	err = json.Unmarshal([]byte(src.Value()), &raw)
+	if err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return obj.NIL, &JSONError{Offset: syntaxErr.Offset, Msg: syntaxErr.Error()}
+		}
+
+		return obj.NIL, err //nolint:wrapcheck
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(src.Value()))
+	decoder.UseNumber()
+
+	return decodeJSON(decoder)
+}
+
+func decodeJSON(decoder *json.Decoder) (obj.Object, error) { //nolint:cyclop
+	token, err := decoder.Token()
+	if err != nil {
+		return obj.NIL, err //nolint:wrapcheck
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		if token == '[' {
+			return decodeJSONArray(decoder)
+		}
+
+		return decodeJSONObject(decoder)
+	case json.Number:
+		if integer, iErr := strconv.ParseInt(token.String(), 10, 64); iErr == nil {
+			return obj.New[obj.Integer](integer), nil
+		}
+
+		float, fErr := token.Float64()
+		if fErr != nil {
+			return obj.NIL, &JSONError{Offset: decoder.InputOffset(), Msg: fErr.Error()}
+		}
+
+		return obj.New[obj.Float](float), nil
+	case string:
+		return obj.New[obj.String](token), nil
+	case bool:
+		return obj.ToBoolean(token), nil
+	default:
+		return obj.NIL, nil
+	}
+}
+
+func decodeJSONArray(decoder *json.Decoder) (obj.Object, error) {
+	elements := []obj.Object{}
+
+	for decoder.More() {
+		element, err := decodeJSON(decoder)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		elements = append(elements, element)
+	}
+
+	_, err := decoder.Token() // ]
+	if err != nil {
+		return obj.NIL, err //nolint:wrapcheck
+	}
+
+	return obj.New[obj.Array](elements), nil
+}
+
+func decodeJSONObject(decoder *json.Decoder) (obj.Object, error) {
+	hash, _ := obj.NewHash()
+
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return obj.NIL, err //nolint:wrapcheck
+		}
+
+		value, err := decodeJSON(decoder)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		err = hash.Set(obj.New[obj.String](key.(string)), value) //nolint:forcetypeassert
+		if err != nil {
+			return obj.NIL, err //nolint:wrapcheck
+		}
+	}
+
+	_, err := decoder.Token() // }
+	if err != nil {
+		return obj.NIL, err //nolint:wrapcheck
+	}
+
+	return hash, nil
+}
+
+// maxIndent is the largest indent of stringify, like in JavaScript.
+const maxIndent = 10
+
+// stringify(value) or stringify(value, indent) encodes the value as JSON, nested values are indented with
+// the number of spaces if it's positive, up to maxIndent. Hash keys must be strings, times are encoded
+// as RFC3339 strings.
+func stringifyJSON(args ...obj.Object) (obj.Object, error) {
+	indent := obj.New[obj.Integer](0)
+
+	if len(args) != 1 {
+		var err error
+
+		_, indent, err = args2[obj.Object, obj.Integer](args)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		if indent.Value() > maxIndent {
+			return obj.NIL, fmt.Errorf("%w: indent %d is greater than %d", ErrInvalidArgument, indent.Value(), maxIndent)
+		}
+	}
+
+	encoder := &jsonEncoder{}
+
+	err := encoder.encode(args[0])
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	if indent.Value() <= 0 {
+		return obj.New[obj.String](encoder.out.String()), nil
+	}
+
+	out := &bytes.Buffer{}
+
+	err = json.Indent(out, encoder.out.Bytes(), "", strings.Repeat(" ", int(indent.Value())))
+	if err != nil {
+		return obj.NIL, err //nolint:wrapcheck
+	}
+
+	return obj.New[obj.String](out.String()), nil
+}
+
+type jsonEncoder struct {
+	out bytes.Buffer
+	// path holds arrays and hashes being encoded, a value met again on the path is a cycle.
+	path []obj.Object
+}
+
+func (e *jsonEncoder) encode(value obj.Object) error { //nolint:cyclop
+	switch value := value.(type) {
+	case obj.Nil:
+		e.out.WriteString("null")
+	case obj.Boolean, obj.Integer:
+		e.out.WriteString(value.Inspect())
+	case obj.Float:
+		if math.IsInf(value.Value(), 0) || math.IsNaN(value.Value()) {
+			return fmt.Errorf("%w: %s can't be represented in JSON", ErrInvalidArgument, value.Inspect())
+		}
+
+		e.out.WriteString(value.Inspect())
+	case obj.String:
+		e.quote(value.Value())
+	case obj.Time:
+		e.quote(value.Inspect())
+	case obj.Array:
+		return e.nested(value, func() error {
+			return e.encodeArray(value)
+		})
+	case obj.Hash:
+		return e.nested(value, func() error {
+			return e.encodeHash(value)
+		})
+	default:
+		return fmt.Errorf("%w: %s can't be represented in JSON", obj.ErrWronArgumentType, value.TypeName())
+	}
+
+	return nil
+}
+
+func (e *jsonEncoder) encodeArray(array obj.Array) error {
+	e.out.WriteByte('[')
+
+	for i, element := range array.Value() {
+		if i > 0 {
+			e.out.WriteByte(',')
+		}
+
+		err := e.encode(element)
+		if err != nil {
+			return err
+		}
+	}
+
+	e.out.WriteByte(']')
+
+	return nil
+}
+
+func (e *jsonEncoder) encodeHash(hash obj.Hash) error {
+	e.out.WriteByte('{')
+
+	for i, pair := range hash.Pairs() {
+		key, ok := pair.Key.(obj.String)
+		if !ok {
+			return fmt.Errorf("%w: keys must be String, given %s", obj.ErrWronArgumentType, pair.Key.TypeName())
+		}
+
+		if i > 0 {
+			e.out.WriteByte(',')
+		}
+
+		e.quote(key.Value())
+		e.out.WriteByte(':')
+
+		err := e.encode(pair.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	e.out.WriteByte('}')
+
+	return nil
+}
+
+// nested encodes a container, it fails if the container is already being encoded.
+func (e *jsonEncoder) nested(value obj.Object, encode func() error) error {
+	for _, parent := range e.path {
+		if same(parent, value) {
+			return ErrCycle
+		}
+	}
+
+	e.path = append(e.path, value)
+	defer func() { e.path = e.path[:len(e.path)-1] }()
+
+	return encode()
+}
+
+func (e *jsonEncoder) quote(s string) {
+	encoder := json.NewEncoder(&e.out)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+
+	e.out.Truncate(e.out.Len() - 1) // Encode appends a newline
+}
+
+// same reports whether both objects are the same container: hashes sharing pairs or arrays sharing elements.
+func same(a, b obj.Object) bool {
+	switch a := a.(type) {
+	case obj.Hash:
+		other, ok := b.(obj.Hash)
+
+		return ok && a.Same(other)
+	case obj.Array:
+		other, ok := b.(obj.Array)
+		if !ok || len(a.Value()) == 0 || len(a.Value()) != len(other.Value()) {
+			return false
+		}
+
+		return reflect.ValueOf(a.Value()).Pointer() == reflect.ValueOf(other.Value()).Pointer()
+	default:
+		return false
+	}
+}
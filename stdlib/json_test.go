@@ -0,0 +1,61 @@
+package stdlib_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+	"github.com/zhulik/monkey/stdlib"
+)
+
+var _ = Describe("JSON", func() {
+	It("exports parse and stringify", func() {
+		Expect(stdlib.JSON(evaluator.New()).Exports).To(ContainElements("parse", "stringify"))
+	})
+
+	It("round-trips values", func() {
+		source := `let v = {"a": [1, 2.5, nil], "b": {"c": true}, "d": ""}; json.parse(json.stringify(v)) == v`
+
+		Expect(lo.Must(eval(source)).Inspect()).To(Equal("true"))
+	})
+
+	It("encodes shared values which are not cycles", func() {
+		source := `let a = [1]; json.stringify([a, a, {"x": a}])`
+
+		Expect(lo.Must(eval(source)).Inspect()).To(Equal(`"[[1],[1],{"x":[1]}]"`))
+	})
+
+	It("detects cycles", func() {
+		hash := lo.Must(obj.NewHash())
+		Expect(hash.Set(obj.New[obj.String]("self"), obj.New[obj.Array]([]obj.Object{hash}))).To(Succeed())
+
+		stringify := lo.Must(stdlib.JSON(evaluator.New()).Member("stringify")).(obj.Builtin) //nolint:forcetypeassert
+
+		_, err := stringify.Fn(hash)
+		Expect(err).To(MatchError(stdlib.ErrCycle))
+	})
+
+	Context("when arguments are invalid", func() {
+		cases := map[string]string{
+			`json.parse(1)`:                            "json.parse: argument type is wrong: argument 1 must be String, given Integer",
+			`json.parse("")`:                           "json.parse: invalid JSON at offset 0: unexpected end of JSON input",
+			`json.parse("[1, ")`:                       "json.parse: invalid JSON at offset 4: unexpected end of JSON input",
+			`json.parse("1 2")`:                        "json.parse: invalid JSON at offset 3: invalid character '2' after top-level value",
+			`json.parse("1e999")`:                      "json.parse: invalid JSON at offset 5: strconv.ParseFloat: parsing \"1e999\": value out of range",
+			`json.stringify()`:                         "json.stringify: wrong number of arguments: expects 2, given 0",
+			`json.stringify(1, "a")`:                   "json.stringify: argument type is wrong: argument 2 must be Integer, given String",
+			`json.stringify([1], 9223372036854775807)`: "json.stringify: invalid argument: indent 9223372036854775807 is greater than 10",
+			`json.stringify({1: 2})`:                   "json.stringify: argument type is wrong: keys must be String, given Integer",
+			`json.stringify([fn() { 1 }])`:             "json.stringify: argument type is wrong: Function can't be represented in JSON",
+			`json.stringify(math.log(0))`:              "json.stringify: invalid argument: -Inf can't be represented in JSON",
+		}
+
+		for source, message := range cases {
+			It("fails on "+source, func() {
+				_, err := eval(source)
+				Expect(err).To(MatchError(message))
+			})
+		}
+	})
+})
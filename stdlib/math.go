@@ -0,0 +1,291 @@
+package stdlib
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+// Math creates the math module. Functions accept any numbers: objects converted with ToFloat and compared
+// with operators, so integers and floats can be mixed.
+func Math(e evaluator.Evaluator) obj.Module {
+	return newModule("math", map[string]function{
+		"abs":    abs,
+		"min":    extremum(obj.OperatorLT.OperatorLT),
+		"max":    extremum(obj.OperatorGT.OperatorGT),
+		"clamp":  clamp,
+		"pow":    pow,
+		"sqrt":   floatFn(math.Sqrt),
+		"sin":    floatFn(math.Sin),
+		"cos":    floatFn(math.Cos),
+		"tan":    floatFn(math.Tan),
+		"asin":   floatFn(math.Asin),
+		"acos":   floatFn(math.Acos),
+		"atan":   floatFn(math.Atan),
+		"atan2":  atan2,
+		"exp":    floatFn(math.Exp),
+		"log":    floatFn(math.Log),
+		"log2":   floatFn(math.Log2),
+		"log10":  floatFn(math.Log10),
+		"floor":  rounding(math.Floor),
+		"ceil":   rounding(math.Ceil),
+		"round":  rounding(math.Round),
+		"random": random(e),
+	}, map[string]obj.Object{
+		"PI": obj.New[obj.Float](math.Pi),
+		"E":  obj.New[obj.Float](math.E),
+	})
+}
+
+// number returns the i-th argument converted to a float.
+func number(args []obj.Object, i int) (float64, error) {
+	num, ok := args[i].(obj.ToFloat)
+	if !ok {
+		return 0, fmt.Errorf("%w: argument %d must be a number, given %s", obj.ErrWronArgumentType, i+1, args[i].TypeName())
+	}
+
+	return num.ToFloat(), nil
+}
+
+// compare applies the comparison operator of a to b.
+func compare[O any](a, b obj.Object, op func(O, obj.Object) (obj.Object, error)) (bool, error) {
+	operator, err := obj.CastOperator[O](a)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s can't be compared", err, a.TypeName())
+	}
+
+	result, err := op(operator, b)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s and %s can't be compared", err, a.TypeName(), b.TypeName())
+	}
+
+	return result == obj.TRUE, nil
+}
+
+// floatFn wraps a function of one float.
+func floatFn(fn func(float64) float64) function {
+	return func(args ...obj.Object) (obj.Object, error) {
+		err := arity(args, 1)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		x, err := number(args, 0)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		return obj.New[obj.Float](fn(x)), nil
+	}
+}
+
+// abs(x) returns x negated if it's less than zero.
+func abs(args ...obj.Object) (obj.Object, error) {
+	err := arity(args, 1)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	negative, err := compare(args[0], obj.New[obj.Integer](0), obj.OperatorLT.OperatorLT)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	if !negative {
+		return args[0], nil
+	}
+
+	minus, err := obj.CastOperator[obj.OperatorPrefixMinus](args[0])
+	if err != nil {
+		return obj.NIL, err //nolint:wrapcheck
+	}
+
+	return minus.OperatorPrefixMinus() //nolint:wrapcheck
+}
+
+// extremum returns min(x, ...) or max(x, ...), an argument replaces the result if it's better according to the operator.
+func extremum[O any](better func(O, obj.Object) (obj.Object, error)) function {
+	return func(args ...obj.Object) (obj.Object, error) {
+		if len(args) == 0 {
+			return obj.NIL, fmt.Errorf("%w: expects at least 1, given 0", obj.ErrWrongNumberOfArguments)
+		}
+
+		result := args[0]
+
+		for _, arg := range args[1:] {
+			replace, err := compare(arg, result, better)
+			if err != nil {
+				return obj.NIL, err
+			}
+
+			if replace {
+				result = arg
+			}
+		}
+
+		return result, nil
+	}
+}
+
+// clamp(x, low, high) limits x to the range.
+func clamp(args ...obj.Object) (obj.Object, error) {
+	err := arity(args, 3) //nolint:mnd
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	x, low, high := args[0], args[1], args[2]
+
+	empty, err := compare(high, low, obj.OperatorLT.OperatorLT)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	if empty {
+		return obj.NIL, fmt.Errorf("%w: low %s is greater than high %s", ErrInvalidArgument, low.Inspect(), high.Inspect())
+	}
+
+	result, err := extremum(obj.OperatorGT.OperatorGT)(low, x)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return extremum(obj.OperatorLT.OperatorLT)(result, high)
+}
+
+// pow(x, y) returns x to the power of y, it's an Integer if both are integers and y is not negative. Integer
+// results out of the Integer range are errors, pass a Float to get a Float result.
+func pow(args ...obj.Object) (obj.Object, error) {
+	err := arity(args, 2) //nolint:mnd
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	base, baseInt := args[0].(obj.Integer)
+	exponent, exponentInt := args[1].(obj.Integer)
+
+	if baseInt && exponentInt && exponent.Value() >= 0 {
+		result, ok := intPow(base.Value(), exponent.Value())
+		if !ok {
+			return obj.NIL, fmt.Errorf("%w: %d to the power of %d is out of the Integer range",
+				ErrInvalidArgument, base.Value(), exponent.Value())
+		}
+
+		return obj.New[obj.Integer](result), nil
+	}
+
+	x, err := number(args, 0)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	y, err := number(args, 1)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.Float](math.Pow(x, y)), nil
+}
+
+// atan2(y, x) returns the arc tangent of y/x using signs of both to determine the quadrant.
+func atan2(args ...obj.Object) (obj.Object, error) {
+	err := arity(args, 2) //nolint:mnd
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	y, err := number(args, 0)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	x, err := number(args, 1)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.Float](math.Atan2(y, x)), nil
+}
+
+// rounding returns floor(x), ceil(x) or round(x), the result is an Integer, integers are returned unchanged.
+func rounding(fn func(float64) float64) function {
+	return func(args ...obj.Object) (obj.Object, error) {
+		err := arity(args, 1)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		if integer, ok := args[0].(obj.Integer); ok {
+			return integer, nil
+		}
+
+		x, err := number(args, 0)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		rounded := fn(x)
+		if math.IsNaN(rounded) || rounded < math.MinInt64 || rounded >= math.MaxInt64 {
+			return obj.NIL, fmt.Errorf("%w: %s is out of the Integer range", ErrInvalidArgument, args[0].Inspect())
+		}
+
+		return obj.New[obj.Integer](int64(rounded)), nil
+	}
+}
+
+// random returns random(), a Float in [0, 1), or random(n), an Integer in [0, n). Numbers are generated
+// by the evaluator's source.
+func random(e evaluator.Evaluator) function {
+	return func(args ...obj.Object) (obj.Object, error) {
+		if len(args) == 0 {
+			return obj.New[obj.Float](e.Rand().Float64()), nil
+		}
+
+		n, err := args1[obj.Integer](args)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		if n.Value() <= 0 {
+			return obj.NIL, fmt.Errorf("%w: non-positive bound %d", ErrInvalidArgument, n.Value())
+		}
+
+		return obj.New[obj.Integer](e.Rand().Int64N(n.Value())), nil
+	}
+}
+
+// intPow raises the base to the non-negative exponent by squaring, ok is false if the result overflows.
+func intPow(base, exponent int64) (int64, bool) {
+	result := int64(1)
+
+	for e := exponent; e > 0; e /= 2 { //nolint:mnd
+		var ok bool
+
+		if e%2 == 1 { //nolint:mnd
+			if result, ok = multiply(result, base); !ok {
+				return 0, false
+			}
+		}
+
+		// The base is squared only if it's used again, its last square may overflow needlessly.
+		if e > 1 {
+			if base, ok = multiply(base, base); !ok {
+				return 0, false
+			}
+		}
+	}
+
+	return result, true
+}
+
+// multiply returns the product, ok is false if it overflows.
+func multiply(a, b int64) (int64, bool) {
+	product := a * b
+	if a != 0 && (product/a != b || a == -1 && b == math.MinInt64) {
+		return 0, false
+	}
+
+	return product, true
+}
@@ -0,0 +1,63 @@
+package stdlib_test
+
+import (
+	"math/rand/v2"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/evaluator"
+	"github.com/zhulik/monkey/stdlib"
+)
+
+var _ = Describe("Math", func() {
+	It("exports constants", func() {
+		Expect(stdlib.Math(evaluator.New()).Exports).To(ContainElements("E", "PI", "abs", "random"))
+	})
+
+	It("generates reproducible random numbers with a seeded source", func() {
+		source := "[math.random(), math.random(10), math.random(10)]"
+
+		first := lo.Must(eval(source, evaluator.WithRandSource(rand.NewPCG(1, 1))))
+		second := lo.Must(eval(source, evaluator.WithRandSource(rand.NewPCG(1, 1))))
+		third := lo.Must(eval(source, evaluator.WithRandSource(rand.NewPCG(2, 2))))
+
+		Expect(first).To(Equal(second))
+		Expect(first).ToNot(Equal(third))
+	})
+
+	Context("when arguments are invalid", func() {
+		cases := map[string]string{
+			`math.sqrt("a")`:      "math.sqrt: argument type is wrong: argument 1 must be a number, given String",
+			`math.sqrt()`:         "math.sqrt: wrong number of arguments: expects 1, given 0",
+			`math.min()`:          "math.min: wrong number of arguments: expects at least 1, given 0",
+			`math.max(1, "a")`:    "math.max: method is not defined: String can't be compared",
+			`math.max("a", "b")`:  "math.max: method is not defined: String can't be compared",
+			`math.min(1.5, true)`: "math.min: method is not defined: Boolean can't be compared",
+			`math.abs(nil)`:       "math.abs: method is not defined: Nil can't be compared",
+			`math.clamp(1, 2, 0)`: "math.clamp: invalid argument: low 2 is greater than high 0",
+			`math.random(0)`:      "math.random: invalid argument: non-positive bound 0",
+			`math.random(1.5)`:    "math.random: argument type is wrong: argument 1 must be Integer, given Float",
+			`math.pow(2, "a")`:    "math.pow: argument type is wrong: argument 2 must be a number, given String",
+			`math.pow(2, 64)`:     "math.pow: invalid argument: 2 to the power of 64 is out of the Integer range",
+			`math.pow(-3, 41)`:    "math.pow: invalid argument: -3 to the power of 41 is out of the Integer range",
+		}
+
+		for source, message := range cases {
+			It("fails on "+source, func() {
+				_, err := eval(source)
+				Expect(err).To(MatchError(message))
+			})
+		}
+	})
+
+	It("raises integers to powers up to the Integer range", func() {
+		Expect(eval("[math.pow(2, 62), math.pow(-2, 63), math.pow(1, 1000), math.pow(0, 0), math.pow(2.0, 64)]")).
+			To(HaveField("Inspect()", "[4611686018427387904, -9223372036854775808, 1, 1, 18446744073709552000.0]"))
+	})
+
+	It("fails to round non-finite floats", func() {
+		_, err := eval("math.floor(math.log(0))")
+		Expect(err).To(MatchError("math.floor: invalid argument: -Inf is out of the Integer range"))
+	})
+})
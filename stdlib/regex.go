@@ -0,0 +1,192 @@
+package stdlib
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+// Regex creates the regex module. Patterns are Regex objects or strings compiled and cached by the evaluator.
+// Matches are Hashes of groups: Integer keys for all groups, 0 is the whole match, and String keys for named
+// groups, groups which didn't participate in the match are nil.
+func Regex(e evaluator.Evaluator) obj.Module {
+	r := regex{e: e}
+
+	return newModule("regex", map[string]function{
+		"compile":  r.compile,
+		"match":    r.match,
+		"find":     r.find,
+		"find_all": r.findAll,
+		"replace":  r.replace,
+	}, nil)
+}
+
+type regex struct {
+	e evaluator.Evaluator
+}
+
+// compile(pattern) returns the Regex.
+func (r regex) compile(args ...obj.Object) (obj.Object, error) {
+	err := arity(args, 1)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	re, err := r.pattern(args, 0)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.Regex](re), nil
+}
+
+// match(pattern, s) reports whether s contains a match of the pattern.
+func (r regex) match(args ...obj.Object) (obj.Object, error) {
+	re, s, err := r.patternAndString(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.ToBoolean(re.MatchString(s)), nil
+}
+
+// find(pattern, s) returns the leftmost match or nil.
+func (r regex) find(args ...obj.Object) (obj.Object, error) {
+	re, s, err := r.patternAndString(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	loc := re.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return obj.NIL, nil
+	}
+
+	return matchHash(re, s, loc), nil
+}
+
+// find_all(pattern, s) returns an Array of all successive matches.
+func (r regex) findAll(args ...obj.Object) (obj.Object, error) {
+	re, s, err := r.patternAndString(args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	matches := []obj.Object{}
+
+	for _, loc := range re.FindAllStringSubmatchIndex(s, -1) {
+		matches = append(matches, matchHash(re, s, loc))
+	}
+
+	return obj.New[obj.Array](matches), nil
+}
+
+// replace(pattern, s, replacement) replaces all matches. The replacement is a String where $1 or ${name} stand
+// for groups, or a function called with the match and returning a String.
+func (r regex) replace(args ...obj.Object) (obj.Object, error) {
+	err := arity(args, 3) //nolint:mnd
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	re, s, err := r.patternAndString(args[:2])
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	switch replacement := args[2].(type) {
+	case obj.String:
+		return obj.New[obj.String](re.ReplaceAllString(s, replacement.Value())), nil
+	case obj.Callable:
+		return replaceFunc(re, s, replacement)
+	default:
+		return obj.NIL, fmt.Errorf("%w: argument 3 must be String or a function, given %s",
+			obj.ErrWronArgumentType, replacement.TypeName())
+	}
+}
+
+// replaceFunc replaces matches with results of the callback, the first error of the callback is returned.
+func replaceFunc(re *regexp.Regexp, s string, callback obj.Callable) (obj.Object, error) {
+	var out strings.Builder
+
+	last := 0
+
+	for _, loc := range re.FindAllStringSubmatchIndex(s, -1) {
+		result, err := callback.Call(matchHash(re, s, loc))
+		if err != nil {
+			return obj.NIL, err //nolint:wrapcheck
+		}
+
+		str, ok := result.(obj.String)
+		if !ok {
+			return obj.NIL, fmt.Errorf("%w: replacement function must return String, returned %s",
+				obj.ErrWronArgumentType, result.TypeName())
+		}
+
+		out.WriteString(s[last:loc[0]])
+		out.WriteString(str.Value())
+
+		last = loc[1]
+	}
+
+	out.WriteString(s[last:])
+
+	return obj.New[obj.String](out.String()), nil
+}
+
+// matchHash builds the Hash of groups of the match at loc, a result of FindStringSubmatchIndex.
+func matchHash(re *regexp.Regexp, s string, loc []int) obj.Hash {
+	hash, _ := obj.NewHash()
+
+	for i, name := range re.SubexpNames() {
+		var group obj.Object = obj.NIL
+		if loc[2*i] >= 0 {
+			group = obj.New[obj.String](s[loc[2*i]:loc[2*i+1]])
+		}
+
+		_ = hash.Set(obj.New[obj.Integer](int64(i)), group)
+
+		if name != "" {
+			_ = hash.Set(obj.New[obj.String](name), group)
+		}
+	}
+
+	return hash
+}
+
+// pattern returns the i-th argument as a compiled regular expression.
+func (r regex) pattern(args []obj.Object, i int) (*regexp.Regexp, error) {
+	switch arg := args[i].(type) {
+	case obj.Regex:
+		return arg.Value(), nil
+	case obj.String:
+		re, err := r.e.Regexp(arg.Value())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+		}
+
+		return re, nil
+	default:
+		return nil, fmt.Errorf("%w: argument %d must be Regex or String, given %s",
+			obj.ErrWronArgumentType, i+1, arg.TypeName())
+	}
+}
+
+func (r regex) patternAndString(args []obj.Object) (*regexp.Regexp, string, error) {
+	err := arity(args, 2) //nolint:mnd
+	if err != nil {
+		return nil, "", err
+	}
+
+	re, err := r.pattern(args, 0)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s, err := argument[obj.String](args, 1)
+
+	return re, s.Value(), err
+}
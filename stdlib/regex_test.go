@@ -0,0 +1,68 @@
+package stdlib_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+	"github.com/zhulik/monkey/stdlib"
+)
+
+var _ = Describe("Regex", func() {
+	evalRegex := func(source string) (string, error) {
+		result, err := eval(`import "regex"; ` + source)
+		if err != nil {
+			return "", err
+		}
+
+		return result.Inspect(), nil
+	}
+
+	It("exports functions", func() {
+		Expect(stdlib.Regex(evaluator.New()).Exports).To(ConsistOf("compile", "find", "find_all", "match", "replace"))
+	})
+
+	Context("when arguments are valid", func() {
+		cases := map[string]string{
+			`regex.compile("a+")`: `regex "a+"`,
+			`[regex.match("^\d+$", "123"), regex.match("^\d+$", "1a")]`:                    "[true, false]",
+			`regex.find("(?P<key>\w+)=(\w+)?", "a= b=2")`:                                  `{0: "a=", 1: "a", "key": "a", 2: nil}`,
+			`regex.find("x", "abc")`:                                                       "nil",
+			`regex.find_all(regex.compile("\d"), "a1b2")`:                                  `[{0: "1"}, {0: "2"}]`,
+			`regex.find_all("\d", "ab")`:                                                   "[]",
+			`regex.replace("(\w)(\d)", "a1 b2", "$2$1")`:                                   `"1a 2b"`,
+			`regex.replace("(?P<n>\d+)", "a1 b22", fn(m) { m["n"] + m["n"] })`:             `"a11 b2222"`,
+			`regex.replace("\d", "a1", fn(m) { if (m[0] == "1") { "one" } else { "?" } })`: `"aone"`,
+			`"2024-03-01" =~ regex.compile("^\d{4}-\d{2}-\d{2}$")`:                         "true",
+		}
+
+		for source, expected := range cases {
+			It("returns "+expected+" for "+source, func() {
+				Expect(evalRegex(source)).To(Equal(expected))
+			})
+		}
+	})
+
+	It("propagates errors of the callback", func() {
+		_, err := evalRegex(`regex.replace("\d", "a1", fn(m) { 1 / 0 })`)
+		Expect(err).To(MatchError(obj.ErrDevisionByZero))
+	})
+
+	Context("when arguments are invalid", func() {
+		cases := map[string]string{
+			`regex.compile("(")`:                   "regex.compile: invalid argument: error parsing regexp: missing closing ): `(`",
+			`regex.match(1, "a")`:                  "regex.match: argument type is wrong: argument 1 must be Regex or String, given Integer",
+			`regex.find("a", 1)`:                   "regex.find: argument type is wrong: argument 2 must be String, given Integer",
+			`regex.replace("a", "a", 1)`:           "regex.replace: argument type is wrong: argument 3 must be String or a function, given Integer",
+			`regex.replace("a", "a", fn(m) { 1 })`: "regex.replace: argument type is wrong: replacement function must return String, returned Integer",
+			`regex.find_all("a")`:                  "regex.find_all: wrong number of arguments: expects 2, given 1",
+		}
+
+		for source, message := range cases {
+			It("fails on "+source, func() {
+				_, err := evalRegex(source)
+				Expect(err).To(MatchError(message))
+			})
+		}
+	})
+})
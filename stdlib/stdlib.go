@@ -0,0 +1,129 @@
+// Package stdlib is the standard library of Monkey: modules implemented in Go and imported by name,
+// for instance `import "strings"`, and global builtins working with collections such as map and filter
+// or creating errors.
+package stdlib
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// maxStringLength limits lengths of strings built by the standard library in bytes, so scripts can't exhaust
+// the memory of the host.
+const maxStringLength = 64 << 20
+
+// Options provides builtins and modules of the standard library to the evaluator, kinds of their errors
+// are named for scripts catching them.
+func Options() []evaluator.Option {
+	return []evaluator.Option{
+		evaluator.WithBuiltins(Builtins),
+		evaluator.WithErrorKind(ErrInvalidArgument, "InvalidArgument"),
+		evaluator.WithErrorKind(ErrInvalidJSON, "InvalidJSON"),
+		evaluator.WithErrorKind(ErrCycle, "Cycle"),
+		evaluator.WithErrorKind(ErrPermissionDenied, "PermissionDenied"),
+		evaluator.WithErrorKind(fs.ErrNotExist, "NotFound"),
+		evaluator.WithModule("fs", FS),
+		evaluator.WithModule("json", JSON),
+		evaluator.WithModule("math", Math),
+		evaluator.WithModule("regex", Regex),
+		evaluator.WithModule("strings", Strings),
+		evaluator.WithModule("time", Time),
+	}
+}
+
+type function func(args ...obj.Object) (obj.Object, error)
+
+// newModule creates a module of builtins named after the module and constant values, errors of the functions
+// are prefixed with their names.
+func newModule(name string, functions map[string]function, values map[string]obj.Object) obj.Module {
+	members := maps.Clone(values)
+	if members == nil {
+		members = map[string]obj.Object{}
+	}
+
+	for fnName, fn := range functions {
+		members[fnName] = builtin(name+"."+fnName, fn)
+	}
+
+	return obj.NewModule(name, members)
+}
+
+// builtin wraps the function, its errors are prefixed with the name.
+func builtin(name string, fn function) obj.Builtin {
+	return obj.Builtin{Name: name, Fn: func(args ...obj.Object) (obj.Object, error) {
+		result, err := fn(args...)
+		if err != nil {
+			return obj.NIL, fmt.Errorf("%s: %w", name, err)
+		}
+
+		return result, nil
+	}}
+}
+
+func arity(args []obj.Object, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("%w: expects %d, given %d", obj.ErrWrongNumberOfArguments, n, len(args))
+	}
+
+	return nil
+}
+
+// argument returns the i-th argument if it has the expected type.
+func argument[T obj.Object](args []obj.Object, i int) (T, error) {
+	arg, ok := args[i].(T)
+	if !ok {
+		return arg, fmt.Errorf("%w: argument %d must be %s, given %s",
+			obj.ErrWronArgumentType, i+1, lo.Empty[T]().TypeName(), args[i].TypeName())
+	}
+
+	return arg, nil
+}
+
+func args1[A obj.Object](args []obj.Object) (A, error) {
+	err := arity(args, 1) //nolint:mnd
+	if err != nil {
+		return lo.Empty[A](), err
+	}
+
+	return argument[A](args, 0)
+}
+
+func args2[A, B obj.Object](args []obj.Object) (A, B, error) {
+	err := arity(args, 2) //nolint:mnd
+	if err != nil {
+		return lo.Empty[A](), lo.Empty[B](), err
+	}
+
+	a, err := argument[A](args, 0)
+	if err != nil {
+		return a, lo.Empty[B](), err
+	}
+
+	b, err := argument[B](args, 1)
+
+	return a, b, err
+}
+
+func args3[A, B, C obj.Object](args []obj.Object) (A, B, C, error) {
+	err := arity(args, 3) //nolint:mnd
+	if err != nil {
+		return lo.Empty[A](), lo.Empty[B](), lo.Empty[C](), err
+	}
+
+	a, b, err := args2[A, B](args[:2])
+	if err != nil {
+		return a, b, lo.Empty[C](), err
+	}
+
+	c, err := argument[C](args, 2) //nolint:mnd
+
+	return a, b, c, err
+}
@@ -0,0 +1,214 @@
+package stdlib
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+// Strings creates the strings module. Lengths, indices and widths are counted in runes, not bytes.
+func Strings(evaluator.Evaluator) obj.Module {
+	return newModule("strings", map[string]function{
+		"split":       split,
+		"join":        join,
+		"trim":        stringFn(strings.TrimSpace),
+		"trim_left":   stringFn(func(s string) string { return strings.TrimLeftFunc(s, unicode.IsSpace) }),
+		"trim_right":  stringFn(func(s string) string { return strings.TrimRightFunc(s, unicode.IsSpace) }),
+		"upper":       stringFn(strings.ToUpper),
+		"lower":       stringFn(strings.ToLower),
+		"replace":     replace,
+		"contains":    predicate(strings.Contains),
+		"starts_with": predicate(strings.HasPrefix),
+		"ends_with":   predicate(strings.HasSuffix),
+		"index":       index,
+		"repeat":      repeat,
+		"pad_left":    pad(true),
+		"pad_right":   pad(false),
+		"len":         length,
+		"slice":       slice,
+	}, nil)
+}
+
+// stringFn wraps a function of one string returning a string.
+func stringFn(fn func(string) string) function {
+	return func(args ...obj.Object) (obj.Object, error) {
+		s, err := args1[obj.String](args)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		return obj.New[obj.String](fn(s.Value())), nil
+	}
+}
+
+// predicate wraps a function of two strings returning a boolean.
+func predicate(fn func(string, string) bool) function {
+	return func(args ...obj.Object) (obj.Object, error) {
+		s, other, err := args2[obj.String, obj.String](args)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		return obj.ToBoolean(fn(s.Value(), other.Value())), nil
+	}
+}
+
+// split(s, sep) returns an Array of substrings of s separated by sep, an empty sep splits s into runes.
+func split(args ...obj.Object) (obj.Object, error) {
+	s, sep, err := args2[obj.String, obj.String](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	parts := lo.Map(strings.Split(s.Value(), sep.Value()), func(part string, _ int) obj.Object {
+		return obj.New[obj.String](part)
+	})
+
+	return obj.New[obj.Array](parts), nil
+}
+
+// join(array, sep) concatenates strings of the array placing sep between them.
+func join(args ...obj.Object) (obj.Object, error) {
+	array, sep, err := args2[obj.Array, obj.String](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	parts := make([]string, len(array.Value()))
+
+	for i, element := range array.Value() {
+		s, ok := element.(obj.String)
+		if !ok {
+			return obj.NIL, fmt.Errorf("%w: element %d must be String, given %s",
+				obj.ErrWronArgumentType, i, element.TypeName())
+		}
+
+		parts[i] = s.Value()
+	}
+
+	return obj.New[obj.String](strings.Join(parts, sep.Value())), nil
+}
+
+// replace(s, old, new) replaces all occurrences of old in s with new.
+func replace(args ...obj.Object) (obj.Object, error) {
+	s, old, replacement, err := args3[obj.String, obj.String, obj.String](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.String](strings.ReplaceAll(s.Value(), old.Value(), replacement.Value())), nil
+}
+
+// index(s, sub) returns the rune index of the first occurrence of sub in s, or -1.
+func index(args ...obj.Object) (obj.Object, error) {
+	s, sub, err := args2[obj.String, obj.String](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	i := strings.Index(s.Value(), sub.Value())
+	if i > 0 {
+		i = utf8.RuneCountInString(s.Value()[:i])
+	}
+
+	return obj.New[obj.Integer](int64(i)), nil
+}
+
+// repeat(s, count) returns s repeated count times.
+func repeat(args ...obj.Object) (obj.Object, error) {
+	s, count, err := args2[obj.String, obj.Integer](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	if count.Value() < 0 {
+		return obj.NIL, fmt.Errorf("%w: negative count %d", ErrInvalidArgument, count.Value())
+	}
+
+	repeated, err := repeatString(s.Value(), count.Value())
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.String](repeated), nil
+}
+
+// repeatString returns s repeated count times unless the result is longer than maxStringLength bytes.
+func repeatString(s string, count int64) (string, error) {
+	if s != "" && count > maxStringLength/int64(len(s)) {
+		return "", fmt.Errorf("%w: result is longer than %d bytes", ErrInvalidArgument, maxStringLength)
+	}
+
+	return strings.Repeat(s, int(count)), nil
+}
+
+// pad returns pad_left(s, width, padding) or pad_right(s, width, padding), they extend s to width runes repeating
+// the padding, s is returned unchanged if it's long enough.
+func pad(left bool) function {
+	return func(args ...obj.Object) (obj.Object, error) {
+		s, width, padding, err := args3[obj.String, obj.Integer, obj.String](args)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		if padding.Value() == "" {
+			return obj.NIL, fmt.Errorf("%w: empty padding", ErrInvalidArgument)
+		}
+
+		if width.Value() > maxStringLength {
+			return obj.NIL, fmt.Errorf("%w: width %d is longer than %d", ErrInvalidArgument, width.Value(), maxStringLength)
+		}
+
+		missing := int(width.Value()) - utf8.RuneCountInString(s.Value())
+		if missing <= 0 {
+			return s, nil
+		}
+
+		runes := []rune(padding.Value())
+
+		repeated, err := repeatString(padding.Value(), int64(missing/len(runes)+1))
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		fill := []rune(repeated)[:missing]
+
+		if left {
+			return obj.New[obj.String](string(fill) + s.Value()), nil
+		}
+
+		return obj.New[obj.String](s.Value() + string(fill)), nil
+	}
+}
+
+// len(s) returns the number of runes in s.
+func length(args ...obj.Object) (obj.Object, error) {
+	s, err := args1[obj.String](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.Integer](int64(utf8.RuneCountInString(s.Value()))), nil
+}
+
+// slice(s, start, end) returns runes of s from start up to but not including end.
+func slice(args ...obj.Object) (obj.Object, error) {
+	s, start, end, err := args3[obj.String, obj.Integer, obj.Integer](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	runes := []rune(s.Value())
+
+	if start.Value() < 0 || end.Value() < start.Value() || end.Value() > int64(len(runes)) {
+		return obj.NIL, fmt.Errorf("%w: [%d:%d] with length %d",
+			obj.ErrIndexOutOfRange, start.Value(), end.Value(), len(runes))
+	}
+
+	return obj.New[obj.String](string(runes[start.Value():end.Value()])), nil
+}
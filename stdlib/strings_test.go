@@ -0,0 +1,51 @@
+package stdlib_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/stdlib"
+)
+
+func eval(source string, opts ...evaluator.Option) (obj.Object, error) {
+	program := lo.Must(parser.New(lexer.New(`import "json"; import "math"; import "strings"; ` + source)).ParseProgram())
+
+	return evaluator.New(append(stdlib.Options(), opts...)...).Eval(program)
+}
+
+var _ = Describe("Strings", func() {
+	It("names builtins after the module", func() {
+		Expect(stdlib.Strings(evaluator.New()).Exports).To(ContainElements("split", "join", "len", "slice"))
+		Expect(lo.Must(eval("strings.split")).Inspect()).To(Equal("builtin strings.split"))
+	})
+
+	Context("when arguments are invalid", func() {
+		cases := map[string]string{
+			`strings.upper()`:                                  "strings.upper: wrong number of arguments: expects 1, given 0",
+			`strings.split("a")`:                               "strings.split: wrong number of arguments: expects 2, given 1",
+			`strings.split("a", 1)`:                            "strings.split: argument type is wrong: argument 2 must be String, given Integer",
+			`strings.join(["a", 1], "")`:                       "strings.join: argument type is wrong: element 1 must be String, given Integer",
+			`strings.join("a", "")`:                            "strings.join: argument type is wrong: argument 1 must be Array, given String",
+			`strings.repeat("a", -1)`:                          "strings.repeat: invalid argument: negative count -1",
+			`strings.pad_left("a", 3, "")`:                     "strings.pad_left: invalid argument: empty padding",
+			`strings.repeat("ab", 9223372036854775807)`:        "strings.repeat: invalid argument: result is longer than 67108864 bytes",
+			`strings.repeat("ab", 33554433)`:                   "strings.repeat: invalid argument: result is longer than 67108864 bytes",
+			`strings.pad_left("a", 9223372036854775807, "xy")`: "strings.pad_left: invalid argument: width 9223372036854775807 is longer than 67108864",
+			`strings.pad_right("", 67108864, "ab")`:            "strings.pad_right: invalid argument: result is longer than 67108864 bytes",
+			`strings.slice("héllo", 2, 1)`:                     "strings.slice: index out of range: [2:1] with length 5",
+			`strings.slice("héllo", 0, 6)`:                     "strings.slice: index out of range: [0:6] with length 5",
+			`strings.replace("a", "a", true)`:                  "strings.replace: argument type is wrong: argument 3 must be String, given Boolean",
+		}
+
+		for source, message := range cases {
+			It("fails on "+source, func() {
+				_, err := eval(source)
+				Expect(err).To(MatchError(message))
+			})
+		}
+	})
+})
@@ -0,0 +1,233 @@
+package stdlib
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // time zones don't depend on the host's database
+
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+)
+
+// Time creates the time module. The current time and sleeping are provided by the evaluator's clock, sleeping
+// is interrupted when the evaluator's context is done. Layouts are Go layouts, for instance "2006-01-02 15:04".
+func Time(e evaluator.Evaluator) obj.Module {
+	return newModule("time", map[string]function{
+		"now":       func(args ...obj.Object) (obj.Object, error) { return now(e, args...) },
+		"since":     func(args ...obj.Object) (obj.Object, error) { return since(e, args...) },
+		"sleep":     func(args ...obj.Object) (obj.Object, error) { return sleep(e, args...) },
+		"parse":     parseTime,
+		"format":    formatTime,
+		"unix":      unix,
+		"from_unix": fromUnix,
+		"in_zone":   inZone,
+		"parts":     parts,
+		"duration":  duration,
+		"millis":    millis,
+	}, map[string]obj.Object{
+		"NANOSECOND":   obj.New[obj.Duration](time.Nanosecond),
+		"MILLISECOND":  obj.New[obj.Duration](time.Millisecond),
+		"SECOND":       obj.New[obj.Duration](time.Second),
+		"MINUTE":       obj.New[obj.Duration](time.Minute),
+		"HOUR":         obj.New[obj.Duration](time.Hour),
+		"RFC3339":      obj.New[obj.String](time.RFC3339),
+		"RFC3339_NANO": obj.New[obj.String](time.RFC3339Nano),
+		"RFC1123":      obj.New[obj.String](time.RFC1123),
+		"DATE_TIME":    obj.New[obj.String](time.DateTime),
+		"DATE_ONLY":    obj.New[obj.String](time.DateOnly),
+		"TIME_ONLY":    obj.New[obj.String](time.TimeOnly),
+	})
+}
+
+// now() returns the current time.
+func now(e evaluator.Evaluator, args ...obj.Object) (obj.Object, error) {
+	err := arity(args, 0)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.Time](e.Clock().Now()), nil
+}
+
+// since(t) returns the Duration elapsed since t.
+func since(e evaluator.Evaluator, args ...obj.Object) (obj.Object, error) {
+	t, err := args1[obj.Time](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.Duration](e.Clock().Now().Sub(t.Value())), nil
+}
+
+// sleep(ms) or sleep(duration) pauses the evaluation, it fails if the evaluator's context is done meanwhile.
+func sleep(e evaluator.Evaluator, args ...obj.Object) (obj.Object, error) {
+	err := arity(args, 1)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	var d time.Duration
+
+	switch arg := args[0].(type) {
+	case obj.Integer:
+		d = time.Duration(arg.Value()) * time.Millisecond
+	case obj.Duration:
+		d = arg.Value()
+	default:
+		return obj.NIL, fmt.Errorf("%w: argument 1 must be Integer or Duration, given %s",
+			obj.ErrWronArgumentType, arg.TypeName())
+	}
+
+	return obj.NIL, e.Clock().Sleep(e.Context(), d) //nolint:wrapcheck
+}
+
+// parse(s), parse(s, layout) or parse(s, layout, zone) parses the time, RFC3339 is the default layout.
+// Times without an offset are in UTC unless the zone is given.
+func parseTime(args ...obj.Object) (obj.Object, error) {
+	layout, location := obj.New[obj.String](time.RFC3339), time.UTC
+
+	var (
+		s   obj.String
+		err error
+	)
+
+	switch len(args) {
+	case 0, 1:
+		s, err = args1[obj.String](args)
+	case 2: //nolint:mnd
+		s, layout, err = args2[obj.String, obj.String](args)
+	default:
+		var zone obj.String
+
+		s, layout, zone, err = args3[obj.String, obj.String, obj.String](args)
+		if err == nil {
+			location, err = loadLocation(zone)
+		}
+	}
+
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	t, err := time.ParseInLocation(layout.Value(), s.Value(), location)
+	if err != nil {
+		return obj.NIL, fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+	}
+
+	return obj.New[obj.Time](t), nil
+}
+
+// format(t) or format(t, layout) formats the time, RFC3339 is the default layout.
+func formatTime(args ...obj.Object) (obj.Object, error) {
+	if len(args) == 1 {
+		t, err := args1[obj.Time](args)
+		if err != nil {
+			return obj.NIL, err
+		}
+
+		return obj.New[obj.String](t.Value().Format(time.RFC3339)), nil
+	}
+
+	t, layout, err := args2[obj.Time, obj.String](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.String](t.Value().Format(layout.Value())), nil
+}
+
+// unix(t) returns the number of seconds elapsed since January 1, 1970 UTC.
+func unix(args ...obj.Object) (obj.Object, error) {
+	t, err := args1[obj.Time](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.Integer](t.Value().Unix()), nil
+}
+
+// from_unix(seconds) returns the UTC time of the Unix timestamp.
+func fromUnix(args ...obj.Object) (obj.Object, error) {
+	seconds, err := args1[obj.Integer](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.Time](time.Unix(seconds.Value(), 0).UTC()), nil
+}
+
+// in_zone(t, zone) returns the same instant in the IANA time zone, for instance "Europe/Berlin".
+func inZone(args ...obj.Object) (obj.Object, error) {
+	t, zone, err := args2[obj.Time, obj.String](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	location, err := loadLocation(zone)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.Time](t.Value().In(location)), nil
+}
+
+// parts(t) returns a Hash of components of the time in its zone.
+func parts(args ...obj.Object) (obj.Object, error) {
+	t, err := args1[obj.Time](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	value := t.Value()
+	zone, _ := value.Zone()
+
+	integer := func(name string, n int) obj.HashPair {
+		return obj.HashPair{Key: obj.New[obj.String](name), Value: obj.New[obj.Integer](int64(n))}
+	}
+
+	return obj.NewHash( //nolint:wrapcheck
+		integer("year", value.Year()),
+		integer("month", int(value.Month())),
+		integer("day", value.Day()),
+		integer("hour", value.Hour()),
+		integer("minute", value.Minute()),
+		integer("second", value.Second()),
+		integer("nanosecond", value.Nanosecond()),
+		obj.HashPair{Key: obj.New[obj.String]("weekday"), Value: obj.New[obj.String](value.Weekday().String())},
+		obj.HashPair{Key: obj.New[obj.String]("zone"), Value: obj.New[obj.String](zone)},
+	)
+}
+
+// duration(s) parses a duration such as "1h30m" or "-1.5s".
+func duration(args ...obj.Object) (obj.Object, error) {
+	s, err := args1[obj.String](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	d, err := time.ParseDuration(s.Value())
+	if err != nil {
+		return obj.NIL, fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+	}
+
+	return obj.New[obj.Duration](d), nil
+}
+
+// millis(d) returns the duration as an Integer number of milliseconds.
+func millis(args ...obj.Object) (obj.Object, error) {
+	d, err := args1[obj.Duration](args)
+	if err != nil {
+		return obj.NIL, err
+	}
+
+	return obj.New[obj.Integer](d.Value().Milliseconds()), nil
+}
+
+func loadLocation(zone obj.String) (*time.Location, error) {
+	location, err := time.LoadLocation(zone.Value())
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown time zone %s", ErrInvalidArgument, zone.Value())
+	}
+
+	return location, nil
+}
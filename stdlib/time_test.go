@@ -0,0 +1,100 @@
+package stdlib_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zhulik/monkey/evaluator"
+	"github.com/zhulik/monkey/stdlib"
+)
+
+// fakeClock advances its time when sleeping.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.now = c.now.Add(d)
+
+	return ctx.Err()
+}
+
+var _ = Describe("Time", func() {
+	var clock *fakeClock
+
+	evalTime := func(source string, opts ...evaluator.Option) (string, error) {
+		result, err := eval(`import "time"; `+source, append(opts, evaluator.WithClock(clock))...)
+		if err != nil {
+			return "", err
+		}
+
+		return result.Inspect(), nil
+	}
+
+	BeforeEach(func() {
+		clock = &fakeClock{now: time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)}
+	})
+
+	It("exports functions and constants", func() {
+		Expect(stdlib.Time(evaluator.New()).Exports).To(ContainElements("now", "parse", "format", "sleep", "HOUR", "RFC3339"))
+	})
+
+	It("uses the clock of the evaluator", func() {
+		Expect(evalTime(`let start = time.now(); time.sleep(1500); [start, time.since(start)]`)).
+			To(Equal("[2024-03-01T12:30:00Z, 1.5s]"))
+	})
+
+	It("stops sleeping when the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := eval(`import "time"; time.sleep(time.HOUR)`, evaluator.WithContext(ctx))
+		Expect(err).To(MatchError(context.Canceled))
+	})
+
+	Context("when arguments are valid", func() {
+		cases := map[string]string{
+			`time.parse("2024-03-01T10:00:00+02:00") == time.parse("2024-03-01T08:00:00Z")`:    "true",
+			`time.format(time.parse("01.03.2024 10:00", "02.01.2006 15:04", "Europe/Berlin"))`: `"2024-03-01T10:00:00+01:00"`,
+			`time.format(time.in_zone(time.now(), "Asia/Tokyo"), time.DATE_TIME)`:              `"2024-03-01 21:30:00"`,
+			`let p = time.parts(time.now()); [p["year"], p["month"], p["weekday"], p["zone"]]`: `[2024, 3, "Friday", "UTC"]`,
+			`time.now() + time.duration("1h30m") - time.MINUTE * 30`:                           "2024-03-01T13:30:00Z",
+			`time.now() - time.from_unix(0) > time.HOUR`:                                       "true",
+			`time.unix(time.from_unix(1700000000))`:                                            "1700000000",
+			`time.millis(time.SECOND / 4)`:                                                     "250",
+			`time.format(time.in_zone(time.now(), ""))`:                                        `"2024-03-01T12:30:00Z"`,
+		}
+
+		for source, expected := range cases {
+			It("returns "+expected+" for "+source, func() {
+				Expect(evalTime(source)).To(Equal(expected))
+			})
+		}
+	})
+
+	Context("when arguments are invalid", func() {
+		cases := map[string]string{
+			`time.now(1)`:                        "time.now: wrong number of arguments: expects 0, given 1",
+			`time.parse("2024")`:                 `time.parse: invalid argument: parsing time "2024" as "2006-01-02T15:04:05Z07:00": cannot parse "" as "-"`,
+			`time.parse("2024", "2006", "Mars")`: "time.parse: invalid argument: unknown time zone Mars",
+			`time.in_zone(time.now(), "Mars")`:   "time.in_zone: invalid argument: unknown time zone Mars",
+			`time.duration("1x")`:                `time.duration: invalid argument: time: unknown unit "x" in duration "1x"`,
+			`time.sleep("1")`:                    "time.sleep: argument type is wrong: argument 1 must be Integer or Duration, given String",
+			`time.format(1)`:                     "time.format: argument type is wrong: argument 1 must be Time, given Integer",
+			`time.now() < 1`:                     "argument type is wrong",
+		}
+
+		for source, message := range cases {
+			It("fails on "+source, func() {
+				_, err := evalTime(source)
+				Expect(err).To(MatchError(message))
+			})
+		}
+	})
+})
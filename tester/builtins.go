@@ -0,0 +1,95 @@
+package tester
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zhulik/monkey/evaluator"
+	obj "github.com/zhulik/monkey/evaluator/object"
+	"github.com/zhulik/monkey/tokens"
+)
+
+var ErrAssertionFailed = errors.New("assertion failed")
+
+type test struct {
+	name string
+	pos  tokens.Position
+	fn   obj.Callable
+}
+
+// suite holds tests registered by a file.
+type suite struct {
+	file      string
+	evaluator evaluator.Evaluator
+	tests     []test
+}
+
+// env returns the global environment of the test file with the testing builtins.
+func (s *suite) env() *obj.Env {
+	env := obj.NewEnv()
+
+	env.Set("test", obj.Builtin{Name: "test", Fn: s.test})
+	env.Set("assert_eq", obj.Builtin{Name: "assert_eq", Fn: s.assertEq})
+
+	return env
+}
+
+// test registers a test: test(name, fn).
+func (s *suite) test(args ...obj.Object) (obj.Object, error) {
+	if len(args) != 2 { //nolint:mnd
+		return obj.NIL, fmt.Errorf("%w: test expects 2, given %d", obj.ErrWrongNumberOfArguments, len(args))
+	}
+
+	name, ok := args[0].(obj.String)
+	if !ok {
+		return obj.NIL, fmt.Errorf("%w: test name must be a String, given: %s", obj.ErrWronArgumentType, args[0].TypeName())
+	}
+
+	fn, ok := args[1].(obj.Callable)
+	if !ok {
+		return obj.NIL, fmt.Errorf("%w: test body must be a Function, given: %s", obj.ErrWronArgumentType, args[1].TypeName())
+	}
+
+	s.tests = append(s.tests, test{name: name.Value(), pos: s.position(), fn: fn})
+
+	return obj.NIL, nil
+}
+
+// assertEq fails the test unless the values are equal: assert_eq(actual, expected).
+func (s *suite) assertEq(args ...obj.Object) (obj.Object, error) {
+	if len(args) != 2 { //nolint:mnd
+		return obj.NIL, fmt.Errorf("%w: assert_eq expects 2, given %d", obj.ErrWrongNumberOfArguments, len(args))
+	}
+
+	actual, expected := args[0], args[1]
+
+	if !equal(actual, expected) {
+		return obj.NIL, fmt.Errorf("%s:%s: %w: expected %s, got %s",
+			s.file, s.position(), ErrAssertionFailed, expected.Inspect(), actual.Inspect())
+	}
+
+	return obj.NIL, nil
+}
+
+// position returns the position of the statement being evaluated.
+func (s *suite) position() tokens.Position {
+	stack := s.evaluator.Stack()
+
+	return stack[len(stack)-1].Node.Pos()
+}
+
+// equal compares values with the == operator, values which don't support it are compared by representation.
+func equal(a, b obj.Object) bool {
+	if a.TypeName() != b.TypeName() {
+		return false
+	}
+
+	op, err := obj.CastOperator[obj.OperatorEQ](a)
+	if err != nil {
+		return a.Inspect() == b.Inspect()
+	}
+
+	result, err := op.OperatorEQ(b)
+
+	return err == nil && result == obj.TRUE
+}
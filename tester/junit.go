@@ -0,0 +1,88 @@
+package tester
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Errors    int         `xml:"errors,attr"`
+	Time      string      `xml:"time,attr"`
+	Cases     []junitCase `xml:"testcase"`
+	SystemErr string      `xml:"system-err,omitempty"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes results in the JUnit XML format, each file is a test suite. Files which could not be
+// evaluated are reported as suite errors.
+func WriteJUnit(w io.Writer, results []FileResult) error {
+	suites := junitSuites{}
+
+	for _, result := range results {
+		suite := junitSuite{Name: result.File, Tests: len(result.Tests), Time: seconds(result.Duration)}
+
+		if result.Err != nil {
+			suite.Errors = 1
+			suite.SystemErr = result.Err.Error()
+		}
+
+		suite.Cases = lo.Map(result.Tests, func(test Result, _ int) junitCase {
+			testCase := junitCase{Name: test.Name, ClassName: result.File, Time: seconds(test.Duration)}
+
+			if test.Err != nil {
+				suite.Failures++
+				testCase.Failure = &junitFailure{Message: test.Err.Error()}
+			}
+
+			return testCase
+		})
+
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return fmt.Errorf("writing report error: %w", err)
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	err = encoder.Encode(suites)
+	if err != nil {
+		return fmt.Errorf("writing report error: %w", err)
+	}
+
+	_, err = io.WriteString(w, "\n")
+	if err != nil {
+		return fmt.Errorf("writing report error: %w", err)
+	}
+
+	return nil
+}
+
+func seconds(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
@@ -0,0 +1,227 @@
+// Package tester runs tests written in Monkey. A test file registers tests with the test builtin:
+//
+//	test("adds", fn() { assert_eq(add(1, 2), 3) })
+//
+// Tests run after the file is evaluated, in the order they were registered.
+package tester
+
+import (
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"regexp"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/coverage"
+	"github.com/zhulik/monkey/evaluator"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/module"
+	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/stdlib"
+	"github.com/zhulik/monkey/tokens"
+)
+
+type Option func(*Runner)
+
+// WithFilter makes the runner skip tests whose names don't match the pattern.
+func WithFilter(filter *regexp.Regexp) Option {
+	return func(r *Runner) {
+		r.filter = filter
+	}
+}
+
+// WithParallelism sets how many files are run at the same time, tests of a file always run sequentially.
+// Defaults to the number of CPUs.
+func WithParallelism(n int) Option {
+	return func(r *Runner) {
+		r.parallelism = max(n, 1)
+	}
+}
+
+// WithCoverage records coverage of the test files.
+func WithCoverage(cov *coverage.Coverage) Option {
+	return func(r *Runner) {
+		r.coverage = cov
+	}
+}
+
+// WithModules sets options of the module loaders, each file gets its own loader.
+func WithModules(opts ...module.Option) Option {
+	return func(r *Runner) {
+		r.moduleOpts = append(r.moduleOpts, opts...)
+	}
+}
+
+// WithSeed makes random numbers of test files reproducible, each file gets a source seeded with the seed.
+func WithSeed(seed uint64) Option {
+	return func(r *Runner) {
+		r.seed = &seed
+	}
+}
+
+// WithDisplayName sets how files are named in results, coverage and errors, by default they are named by their
+// paths in the filesystem.
+func WithDisplayName(name func(file string) string) Option {
+	return func(r *Runner) {
+		r.displayName = name
+	}
+}
+
+// Result is an outcome of a single test, Err is nil when the test passed.
+type Result struct {
+	Name     string
+	Pos      tokens.Position // position of the test call
+	Duration time.Duration
+	Err      error
+}
+
+func (r Result) Passed() bool {
+	return r.Err == nil
+}
+
+// FileResult is an outcome of a test file, Err is set when the file could not be loaded or evaluated,
+// in this case none of its tests were run.
+type FileResult struct {
+	File     string
+	Tests    []Result
+	Duration time.Duration
+	Err      error
+}
+
+func (r FileResult) Passed() bool {
+	if r.Err != nil {
+		return false
+	}
+
+	for _, test := range r.Tests {
+		if !test.Passed() {
+			return false
+		}
+	}
+
+	return true
+}
+
+type Runner struct {
+	fsys        fs.FS
+	filter      *regexp.Regexp
+	parallelism int
+	coverage    *coverage.Coverage
+	moduleOpts  []module.Option
+	seed        *uint64
+	displayName func(file string) string
+}
+
+// New creates a runner which reads test files and the modules they import from the filesystem.
+func New(fsys fs.FS, opts ...Option) *Runner {
+	runner := &Runner{
+		fsys:        fsys,
+		parallelism: runtime.NumCPU(),
+		displayName: func(file string) string { return file },
+	}
+
+	for _, opt := range opts {
+		opt(runner)
+	}
+
+	return runner
+}
+
+// Run runs the test files concurrently, results are returned in the order of files.
+func (r *Runner) Run(files []string) []FileResult {
+	results := make([]FileResult, len(files))
+	semaphore := make(chan struct{}, r.parallelism)
+
+	var wg sync.WaitGroup
+
+	for i, file := range files {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i] = r.RunFile(file)
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// RunFile evaluates the file in a fresh evaluator and runs the tests it registered.
+func (r *Runner) RunFile(file string) FileResult {
+	start := time.Now()
+	result := FileResult{File: r.displayName(file)}
+
+	result.Tests, result.Err = r.runFile(file)
+	result.Duration = time.Since(start)
+
+	return result
+}
+
+func (r *Runner) runFile(file string) ([]Result, error) {
+	name := r.displayName(file)
+
+	src, err := fs.ReadFile(r.fsys, file)
+	if err != nil {
+		return nil, fmt.Errorf("reading file error: %w", err)
+	}
+
+	program, err := parser.New(lexer.New(string(src))).ParseProgram()
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", name, err)
+	}
+
+	moduleOpts := r.moduleOpts
+
+	if r.coverage != nil {
+		r.coverage.Add(name, string(src), program)
+
+		// Imported modules are covered too, they are the code under test.
+		cover := module.WithParseHook(func(file, source string, program *ast.Program) {
+			r.coverage.Add(r.displayName(file), source, program)
+		})
+		moduleOpts = append(slices.Clip(moduleOpts), cover)
+	}
+
+	opts := append(stdlib.Options(), evaluator.WithFile(file), module.New(r.fsys, moduleOpts...).Option())
+
+	if r.seed != nil {
+		opts = append(opts, evaluator.WithRandSource(rand.NewPCG(*r.seed, *r.seed)))
+	}
+
+	if r.coverage != nil {
+		opts = append(opts, r.coverage.Options()...)
+	}
+
+	suite := &suite{file: name, evaluator: evaluator.New(opts...)}
+
+	_, err = suite.evaluator.Eval(program, suite.env())
+	if err != nil {
+		return nil, fmt.Errorf("%s: evaluation error: %w", name, err)
+	}
+
+	results := []Result{}
+
+	for _, test := range suite.tests {
+		if r.filter != nil && !r.filter.MatchString(test.name) {
+			continue
+		}
+
+		start := time.Now()
+
+		_, err := test.fn.Call()
+
+		results = append(results, Result{Name: test.name, Pos: test.pos, Duration: time.Since(start), Err: err})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,196 @@
+package tester_test
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing/fstest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/coverage"
+	obj "github.com/zhulik/monkey/evaluator/object"
+	"github.com/zhulik/monkey/tester"
+	"github.com/zhulik/monkey/tokens"
+)
+
+const mathTest = `let add = fn(a, b) { a + b };
+
+test("adds", fn() {
+  assert_eq(add(1, 2), 3)
+});
+
+test("subtracts", fn() {
+  assert_eq(add(1, 2), 4)
+});
+
+test("divides", fn() { 1 / 0 });
+`
+
+var _ = Describe("Runner", func() {
+	const math = "math_test.mk"
+
+	var fsys fstest.MapFS
+
+	write := func(name, source string) string {
+		fsys[name] = &fstest.MapFile{Data: []byte(source)}
+
+		return name
+	}
+
+	BeforeEach(func() {
+		fsys = fstest.MapFS{}
+		write(math, mathTest)
+	})
+
+	Describe(".RunFile", func() {
+		It("runs registered tests", func() {
+			result := tester.New(fsys).RunFile(math)
+
+			Expect(result.Err).ToNot(HaveOccurred())
+			Expect(result.Passed()).To(BeFalse())
+			Expect(result.Tests).To(HaveLen(3))
+
+			Expect(result.Tests[0].Name).To(Equal("adds"))
+			Expect(result.Tests[0].Pos).To(Equal(tokens.Position{Offset: 31, Line: 3, Column: 1}))
+			Expect(result.Tests[0].Passed()).To(BeTrue())
+
+			Expect(result.Tests[1].Err).To(MatchError(tester.ErrAssertionFailed))
+			Expect(result.Tests[1].Err).To(MatchError(math + ":8:3: assertion failed: expected 4, got 3"))
+
+			Expect(result.Tests[2].Err).To(MatchError(obj.ErrDevisionByZero))
+		})
+
+		It("skips tests not matching the filter", func() {
+			result := tester.New(fsys, tester.WithFilter(regexp.MustCompile("^add"))).RunFile(math)
+
+			Expect(result.Passed()).To(BeTrue())
+			Expect(lo.Map(result.Tests, func(test tester.Result, _ int) string { return test.Name })).To(Equal([]string{"adds"}))
+		})
+
+		It("names files with the display name", func() {
+			result := tester.New(fsys, tester.WithDisplayName(strings.ToUpper)).RunFile(math)
+
+			Expect(result.File).To(Equal("MATH_TEST.MK"))
+			Expect(result.Tests[1].Err).To(MatchError("MATH_TEST.MK:8:3: assertion failed: expected 4, got 3"))
+		})
+
+		It("compares values of different types as unequal", func() {
+			result := tester.New(fsys).RunFile(write("types_test.mk", `test("types", fn() { assert_eq(1, "1") })`))
+
+			Expect(result.Tests[0].Err).To(MatchError(ContainSubstring(`expected "1", got 1`)))
+		})
+
+		It("returns a positioned error when the file is invalid", func() {
+			result := tester.New(fsys).RunFile(write("invalid_test.mk", "1 +\n  )"))
+
+			Expect(result.Passed()).To(BeFalse())
+			Expect(result.Err).To(MatchError(ContainSubstring("invalid_test.mk:2:3: no prefix parse function found for )")))
+		})
+
+		It("returns an error when test is misused", func() {
+			result := tester.New(fsys).RunFile(write("misused_test.mk", `test("name", 1)`))
+
+			Expect(result.Err).To(MatchError(obj.ErrWronArgumentType))
+		})
+
+		It("imports modules relative to the test file", func() {
+			write("calc.mk", "export let add = fn(a, b) { a + b }")
+
+			result := tester.New(fsys).RunFile(write("import_test.mk", `import { add } from "calc"; test("adds", fn() { assert_eq(add(1, 2), 3) })`))
+
+			Expect(result.Passed()).To(BeTrue())
+		})
+
+		It("imports modules of the standard library", func() {
+			result := tester.New(fsys).RunFile(write("strings_test.mk", `import "strings"; test("upper", fn() { assert_eq(strings.upper("a"), "A") })`))
+
+			Expect(result.Passed()).To(BeTrue())
+		})
+
+		It("seeds random numbers of every file", func() {
+			source := `import "math"; let a = math.random(1000); test("random", fn() { assert_eq(a, math.random(1000)) })`
+			runner := tester.New(fsys, tester.WithSeed(1))
+
+			first := runner.RunFile(write("random_test.mk", source))
+			second := runner.RunFile("random_test.mk")
+
+			Expect(first.Tests[0].Err).To(HaveOccurred())
+			Expect(second.Tests[0].Err).To(MatchError(first.Tests[0].Err.Error()))
+		})
+
+		It("records coverage", func() {
+			cov := coverage.New()
+			tester.New(fsys, tester.WithCoverage(cov), tester.WithFilter(regexp.MustCompile("adds"))).RunFile(math)
+
+			report := cov.Report()
+			Expect(report).To(HaveLen(1))
+			Expect(report[0].Lines).To(HaveKeyWithValue(4, 1))
+			Expect(report[0].Lines).To(HaveKeyWithValue(8, 0))
+		})
+
+		It("records coverage of imported modules", func() {
+			write("calc.mk", "export let add = fn(a, b) {\n  a + b\n};\nexport let sub = fn(a, b) {\n  a - b\n};")
+
+			cov := coverage.New()
+			runner := tester.New(fsys, tester.WithCoverage(cov))
+			runner.RunFile(write("add_test.mk", `import { add } from "calc"; test("adds", fn() { assert_eq(add(1, 2), 3) })`))
+			runner.RunFile(write("sub_test.mk", `import { sub } from "calc"; test("subs", fn() { assert_eq(sub(2, 1), 1) })`))
+
+			report := cov.Report()
+			Expect(lo.Map(report, func(r coverage.FileReport, _ int) string { return r.Name })).
+				To(Equal([]string{"add_test.mk", "calc.mk", "sub_test.mk"}))
+			Expect(report[1].Lines).To(Equal(map[int]int{1: 2, 2: 1, 4: 2, 5: 1}))
+		})
+	})
+
+	Describe(".Run", func() {
+		It("returns results in the order of files", func() {
+			files := []string{
+				write("a_test.mk", `test("a", fn() { assert_eq(1, 1) })`),
+				math,
+				write("b_test.mk", `test("b", fn() { assert_eq(true, true) })`),
+			}
+
+			results := tester.New(fsys, tester.WithParallelism(2)).Run(files)
+
+			Expect(lo.Map(results, func(result tester.FileResult, _ int) string { return result.File })).To(Equal(files))
+			Expect(lo.Map(results, func(result tester.FileResult, _ int) bool { return result.Passed() })).
+				To(Equal([]bool{true, false, true}))
+		})
+	})
+})
+
+var _ = Describe("WriteJUnit", func() {
+	It("writes a JUnit XML report", func() {
+		results := []tester.FileResult{
+			{
+				File:     "math_test.mk",
+				Duration: 1500 * time.Millisecond,
+				Tests: []tester.Result{
+					{Name: "adds", Duration: time.Second},
+					{Name: "subtracts", Duration: 500 * time.Millisecond, Err: tester.ErrAssertionFailed},
+				},
+			},
+			{File: "invalid_test.mk", Err: obj.ErrUnknownIdentifier},
+		}
+
+		out := &bytes.Buffer{}
+		Expect(tester.WriteJUnit(out, results)).To(Succeed())
+		Expect(out.String()).To(Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="math_test.mk" tests="2" failures="1" errors="0" time="1.500">
+    <testcase name="adds" classname="math_test.mk" time="1.000"></testcase>
+    <testcase name="subtracts" classname="math_test.mk" time="0.500">
+      <failure message="assertion failed"></failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="invalid_test.mk" tests="0" failures="0" errors="1" time="0.000">
+    <system-err>identifier is unknown</system-err>
+  </testsuite>
+</testsuites>
+`))
+	})
+})
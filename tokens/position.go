@@ -0,0 +1,20 @@
+package tokens
+
+import "fmt"
+
+// Position points to a byte in the source, Line and Column are 1-based.
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Span covers source from Start up to, but not including, End.
+type Span struct {
+	Start Position
+	End   Position
+}
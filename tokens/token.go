@@ -1,12 +1,21 @@
 package tokens
 
+import (
+	"slices"
+
+	"github.com/samber/lo"
+)
+
 type TokenType string
 
 const (
 	// Can have literal.
 	IDENTIFIER TokenType = "IDENTIFIER"
 	INTEGER    TokenType = "INTEGER"
+	FLOAT      TokenType = "FLOAT"
 	STRING     TokenType = "STRING"
+	COMMENT    TokenType = "COMMENT"
+	EOF        TokenType = "EOF"
 
 	// Literal is equal to the type itself.
 	ASSIGN   TokenType = "="
@@ -21,17 +30,23 @@ const (
 	GTE TokenType = ">="
 	LTE TokenType = "<="
 
-	EQ  TokenType = "=="
-	NEQ TokenType = "!="
+	EQ    TokenType = "=="
+	NEQ   TokenType = "!="
+	MATCH TokenType = "=~"
 
 	COMMA     TokenType = ","
 	SEMICOLON TokenType = ";"
+	COLON     TokenType = ":"
+	DOT       TokenType = "."
 
 	LPAREN TokenType = "("
 	RPAREN TokenType = ")"
 	LBRACE TokenType = "{"
 	RBRACE TokenType = "}"
 
+	LBRACKET TokenType = "["
+	RBRACKET TokenType = "]"
+
 	FUNCTION TokenType = "fn"
 	LET      TokenType = "let"
 	TRUE     TokenType = "true"
@@ -40,6 +55,13 @@ const (
 	ELSE     TokenType = "else"
 	RETURN   TokenType = "return"
 	NIL      TokenType = "nil"
+	IMPORT   TokenType = "import"
+	EXPORT   TokenType = "export"
+	FROM     TokenType = "from"
+	TRY      TokenType = "try"
+	CATCH    TokenType = "catch"
+	FINALLY  TokenType = "finally"
+	THROW    TokenType = "throw"
 )
 
 var keywords = map[TokenType]TokenType{ //nolint:gochecknoglobals
@@ -51,6 +73,13 @@ var keywords = map[TokenType]TokenType{ //nolint:gochecknoglobals
 	ELSE:     ELSE,
 	RETURN:   RETURN,
 	NIL:      NIL,
+	IMPORT:   IMPORT,
+	EXPORT:   EXPORT,
+	FROM:     FROM,
+	TRY:      TRY,
+	CATCH:    CATCH,
+	FINALLY:  FINALLY,
+	THROW:    THROW,
 }
 
 type Token struct {
@@ -75,8 +104,24 @@ func TypeFromLiteral(lit string) TokenType {
 	return IDENTIFIER
 }
 
+// Keywords returns all reserved words of the language, sorted.
+func Keywords() []TokenType {
+	kws := lo.Keys(keywords)
+	slices.Sort(kws)
+
+	return kws
+}
+
+func IsKeyword(tokenType TokenType) bool {
+	_, ok := keywords[tokenType]
+
+	return ok
+}
+
+// Literal returns the source of the token, tokens of fixed spelling like operators and keywords are spelled
+// as their type. Strings may be empty.
 func (t Token) Literal() string {
-	if t.literal != "" {
+	if t.literal != "" || t.Type == STRING {
 		return t.literal
 	}
 
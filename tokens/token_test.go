@@ -0,0 +1,26 @@
+package tokens_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zhulik/monkey/tokens"
+)
+
+var _ = Describe("Tokens", func() {
+	Describe(".Keywords", func() {
+		It("returns sorted keywords", func() {
+			Expect(tokens.Keywords()).To(Equal([]tokens.TokenType{
+				tokens.CATCH, tokens.ELSE, tokens.EXPORT, tokens.FALSE, tokens.FINALLY, tokens.FUNCTION, tokens.FROM, tokens.IF,
+				tokens.IMPORT, tokens.LET, tokens.NIL, tokens.RETURN, tokens.THROW, tokens.TRUE, tokens.TRY,
+			}))
+		})
+	})
+
+	Describe(".IsKeyword", func() {
+		It("returns true for keywords only", func() {
+			Expect(tokens.IsKeyword(tokens.LET)).To(BeTrue())
+			Expect(tokens.IsKeyword(tokens.IDENTIFIER)).To(BeFalse())
+			Expect(tokens.IsKeyword(tokens.PLUS)).To(BeFalse())
+		})
+	})
+})
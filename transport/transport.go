@@ -0,0 +1,45 @@
+// Package transport reads and writes messages framed with a Content-Length header, the framing is shared
+// by the Language Server Protocol and the Debug Adapter Protocol.
+package transport
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+)
+
+var ErrMissingContentLength = errors.New("missing Content-Length header")
+
+// ReadMessage reads a single message framed with a Content-Length header.
+func ReadMessage(reader *bufio.Reader) ([]byte, error) {
+	header, err := textproto.NewReader(reader).ReadMIMEHeader()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMissingContentLength, err)
+	}
+
+	body := make([]byte, length)
+
+	_, err = io.ReadFull(reader, body)
+	if err != nil {
+		return nil, fmt.Errorf("reading message body error: %w", err)
+	}
+
+	return body, nil
+}
+
+func WriteMessage(writer io.Writer, body []byte) error {
+	_, err := fmt.Fprintf(writer, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	if err != nil {
+		return fmt.Errorf("writing message error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package transport_test
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zhulik/monkey/transport"
+)
+
+var _ = Describe("Transport", func() {
+	It("reads written messages", func() {
+		buffer := &bytes.Buffer{}
+
+		Expect(transport.WriteMessage(buffer, []byte(`{"a":1}`))).To(Succeed())
+		Expect(transport.WriteMessage(buffer, []byte(`{"é":2}`))).To(Succeed())
+		Expect(buffer.String()).To(HavePrefix("Content-Length: 7\r\n\r\n{\"a\":1}Content-Length: 8\r\n"))
+
+		reader := bufio.NewReader(buffer)
+
+		Expect(transport.ReadMessage(reader)).To(Equal([]byte(`{"a":1}`)))
+		Expect(transport.ReadMessage(reader)).To(Equal([]byte(`{"é":2}`)))
+
+		_, err := transport.ReadMessage(reader)
+		Expect(err).To(MatchError(io.EOF))
+	})
+
+	It("accepts other headers", func() {
+		reader := bufio.NewReader(strings.NewReader("Content-Type: application/json\r\nContent-Length: 2\r\n\r\n{}"))
+
+		Expect(transport.ReadMessage(reader)).To(Equal([]byte(`{}`)))
+	})
+
+	It("fails without Content-Length", func() {
+		_, err := transport.ReadMessage(bufio.NewReader(strings.NewReader("Foo: bar\r\n\r\n{}")))
+		Expect(err).To(MatchError(transport.ErrMissingContentLength))
+	})
+
+	It("fails on truncated body", func() {
+		_, err := transport.ReadMessage(bufio.NewReader(strings.NewReader("Content-Length: 10\r\n\r\n{}")))
+		Expect(err).To(MatchError(io.ErrUnexpectedEOF))
+	})
+})
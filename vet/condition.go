@@ -0,0 +1,31 @@
+package vet
+
+import "github.com/zhulik/monkey/ast"
+
+var Condition = &Analyzer{ //nolint:gochecknoglobals
+	Name: "condition",
+	Doc:  "reports if expressions with a condition which can never be a boolean",
+	Run: func(pass *Pass) {
+		ast.Inspect(pass.Program, func(node ast.Node) bool {
+			expr, ok := node.(*ast.IfExpression)
+			if ok && !maybeBoolean(expr.V) {
+				pass.Report(expr.V, "non-boolean condition in if expression")
+			}
+
+			return true
+		})
+	},
+}
+
+// maybeBoolean reports whether the expression may evaluate to a boolean.
+func maybeBoolean(expr ast.Expression) bool {
+	switch expr := expr.(type) {
+	case *ast.IntegerExpression, *ast.FloatExpression, *ast.StringExpression, *ast.NilExpression, *ast.FunctionExpression,
+		*ast.ArrayExpression, *ast.HashExpression:
+		return false
+	case *ast.PrefixExpression:
+		return expr.Operator != "-"
+	default:
+		return true
+	}
+}
@@ -0,0 +1,20 @@
+package vet
+
+var Shadow = &Analyzer{ //nolint:gochecknoglobals
+	Name: "shadow",
+	Doc:  "reports let bindings and function parameters which shadow a binding of an enclosing function",
+	Run: func(pass *Pass) {
+		for _, binding := range pass.Scope.Bindings() {
+			if binding.Scope.Parent == nil {
+				continue
+			}
+
+			shadowed := binding.Scope.Parent.Lookup(binding.Name)
+			if shadowed == nil {
+				continue
+			}
+
+			pass.Report(binding.Ident, "declaration of %s shadows declaration at %s", binding.Name, shadowed.Ident.Pos())
+		}
+	},
+}
@@ -0,0 +1,59 @@
+package vet
+
+import "github.com/zhulik/monkey/ast"
+
+var Unreachable = &Analyzer{ //nolint:gochecknoglobals
+	Name: "unreachable",
+	Doc:  "reports statements which follow a return or throw statement, or an if or try expression leaving all branches",
+	Run: func(pass *Pass) {
+		ast.Inspect(pass.Program, func(node ast.Node) bool {
+			var statements []ast.Statement
+
+			switch node := node.(type) {
+			case *ast.Program:
+				statements = node.Statements
+			case *ast.BlockStatement:
+				statements = node.V
+			}
+
+			for i, stmt := range statements[:max(len(statements)-1, 0)] {
+				if terminates(stmt) {
+					pass.Report(statements[i+1], "unreachable code")
+
+					break
+				}
+			}
+
+			return true
+		})
+	},
+}
+
+func terminates(stmt ast.Statement) bool {
+	switch stmt := stmt.(type) {
+	case *ast.ReturnStatement, *ast.ThrowStatement:
+		return true
+	case *ast.ExpressionStatement:
+		switch expr := stmt.V.(type) {
+		case *ast.IfExpression:
+			return expr.Else != nil && blockTerminates(expr.Then) && blockTerminates(expr.Else)
+		case *ast.TryExpression:
+			return expr.Finally != nil && blockTerminates(expr.Finally) ||
+				blockTerminates(expr.V) && (expr.Catch == nil || blockTerminates(expr.Catch))
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+}
+
+func blockTerminates(block *ast.BlockStatement) bool {
+	for _, stmt := range block.V {
+		if terminates(stmt) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,24 @@
+package vet
+
+import "github.com/zhulik/monkey/scope"
+
+var Unused = &Analyzer{ //nolint:gochecknoglobals
+	Name: "unused",
+	Doc:  "reports let bindings and imports which are never used, exported names and names starting with _ are ignored",
+	Run: func(pass *Pass) {
+		for _, binding := range pass.Scope.Bindings() {
+			switch {
+			case binding.Kind == scope.Parameter, binding.Kind == scope.Catch:
+				continue
+			case binding.Exported, len(binding.Uses) > 0, binding.Name[0] == '_':
+				continue
+			}
+
+			if binding.Kind == scope.Import {
+				pass.Report(binding.Ident, "%s imported and not used", binding.Name)
+			} else {
+				pass.Report(binding.Ident, "%s declared and not used", binding.Name)
+			}
+		}
+	},
+}
@@ -0,0 +1,166 @@
+// Package vet reports suspicious constructs in Monkey programs.
+package vet
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/lexer"
+	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/scope"
+	"github.com/zhulik/monkey/tokens"
+)
+
+// DisableDirective disables analyzers for the whole file when found in a comment, it's followed by
+// space-separated analyzer names or by nothing to disable all of them: `// vet:disable unused shadow`.
+const DisableDirective = "vet:disable"
+
+var registry = map[string]*Analyzer{ //nolint:gochecknoglobals
+	Condition.Name:   Condition,
+	Shadow.Name:      Shadow,
+	Unreachable.Name: Unreachable,
+	Unused.Name:      Unused,
+}
+
+// Analyzer is a single check.
+type Analyzer struct {
+	Name string
+	Doc  string
+	Run  func(pass *Pass)
+}
+
+// Pass is passed to an analyzer's Run, it holds the program being analyzed.
+type Pass struct {
+	Analyzer *Analyzer
+	Program  *ast.Program
+	Scope    *scope.Info
+
+	diagnostics []Diagnostic
+}
+
+// Report adds a diagnostic for the node.
+func (p *Pass) Report(node ast.Node, format string, args ...any) {
+	p.diagnostics = append(p.diagnostics, Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Analyzer: p.Analyzer.Name,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+type Diagnostic struct {
+	Pos      tokens.Position
+	End      tokens.Position
+	Analyzer string
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s (%s)", d.Pos, d.Message, d.Analyzer)
+}
+
+// Register adds the analyzer to the registry, an analyzer with the same name is replaced.
+func Register(analyzer *Analyzer) {
+	registry[analyzer.Name] = analyzer
+}
+
+// Unregister removes the analyzer from the registry.
+func Unregister(name string) {
+	delete(registry, name)
+}
+
+// Lookup returns the registered analyzer with the name.
+func Lookup(name string) (*Analyzer, bool) {
+	analyzer, ok := registry[name]
+
+	return analyzer, ok
+}
+
+// Analyzers returns all registered analyzers sorted by name.
+func Analyzers() []*Analyzer {
+	analyzers := lo.Values(registry)
+
+	slices.SortFunc(analyzers, func(a, b *Analyzer) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return analyzers
+}
+
+// Run runs the analyzers over the program and returns their diagnostics sorted by position.
+func Run(program *ast.Program, analyzers ...*Analyzer) []Diagnostic {
+	info := scope.Resolve(program)
+	diagnostics := []Diagnostic{}
+
+	for _, analyzer := range analyzers {
+		pass := &Pass{Analyzer: analyzer, Program: program, Scope: info}
+		analyzer.Run(pass)
+
+		diagnostics = append(diagnostics, pass.diagnostics...)
+	}
+
+	slices.SortStableFunc(diagnostics, func(a, b Diagnostic) int {
+		return a.Pos.Offset - b.Pos.Offset
+	})
+
+	return diagnostics
+}
+
+// Source parses the source and runs the analyzers not disabled by the file's directives,
+// all registered analyzers are used if none given.
+func Source(src []byte, analyzers ...*Analyzer) ([]Diagnostic, error) {
+	program, err := parser.New(lexer.New(string(src))).ParseProgram()
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %w", err)
+	}
+
+	if len(analyzers) == 0 {
+		analyzers = Analyzers()
+	}
+
+	disabled, all := directives(src)
+	if all {
+		return []Diagnostic{}, nil
+	}
+
+	analyzers = lo.Reject(analyzers, func(analyzer *Analyzer, _ int) bool {
+		return lo.Contains(disabled, analyzer.Name)
+	})
+
+	return Run(program, analyzers...), nil
+}
+
+// directives returns analyzer names disabled in the source's comments, all is true if every analyzer is disabled.
+func directives(src []byte) ([]string, bool) {
+	disabled := []string{}
+
+	for token, err := range lexer.New(string(src), lexer.WithComments()).IterateTokens() {
+		if err != nil {
+			break // the source has been parsed already
+		}
+
+		if token.Type != tokens.COMMENT {
+			continue
+		}
+
+		text := strings.TrimPrefix(token.Literal(), "//")
+		text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+
+		names, ok := strings.CutPrefix(strings.TrimSpace(text), DisableDirective)
+		if !ok {
+			continue
+		}
+
+		fields := strings.Fields(names)
+		if len(fields) == 0 {
+			return nil, true
+		}
+
+		disabled = append(disabled, fields...)
+	}
+
+	return disabled, false
+}
@@ -0,0 +1,138 @@
+package vet_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/zhulik/monkey/ast"
+	"github.com/zhulik/monkey/parser"
+	"github.com/zhulik/monkey/vet"
+)
+
+func check(input string, analyzers ...*vet.Analyzer) []string {
+	diagnostics, err := vet.Source([]byte(input), analyzers...)
+	Expect(err).ToNot(HaveOccurred())
+
+	return lo.Map(diagnostics, func(d vet.Diagnostic, _ int) string { return d.String() })
+}
+
+var _ = Describe("Vet", func() {
+	Describe("analyzers", func() {
+		cases := map[*vet.Analyzer]map[string][]string{
+			vet.Unused: {
+				"let a = 1; a":                       {},
+				"let a = 1":                          {"1:5: a declared and not used (unused)"},
+				"let _a = 1":                         {},
+				"let a = 1; let a = a + 1":           {"1:16: a declared and not used (unused)"},
+				"let f = fn(x) { 1 }; f()":           {},
+				"let f = fn() { let b = 2; 1 }; f":   {"1:20: b declared and not used (unused)"},
+				"let f = fn() { g() }; let g = 1; f": {},
+				"export let a = 1":                   {},
+				`import "lib/m"`:                     {"1:8: m imported and not used (unused)"},
+				`import { a, b } from "m"; a`:        {"1:13: b imported and not used (unused)"},
+				"try { 1 } catch (e) { 2 }":          {},
+			},
+			vet.Shadow: {
+				"let x = 1; fn(x) { x }":            {"1:15: declaration of x shadows declaration at 1:5 (shadow)"},
+				"let x = 1; fn() { let x = 2; x }":  {"1:23: declaration of x shadows declaration at 1:5 (shadow)"},
+				"let x = 1; let x = 2":              {},
+				"fn(x) { fn(y) { x + y } }":         {},
+				"fn(x) { if (x) { let y = 1; y } }": {},
+				"fn(x) { fn(x) { x } }":             {"1:12: declaration of x shadows declaration at 1:4 (shadow)"},
+			},
+			vet.Unreachable: {
+				"return 1; 2":                                       {"1:11: unreachable code (unreachable)"},
+				"fn() { return 1; let a = 2; a }":                   {"1:18: unreachable code (unreachable)"},
+				"fn() { if (a) { return 1; } 2 }":                   {},
+				"fn() { if (a) { return 1 } else { return 2 }; 3 }": {"1:47: unreachable code (unreachable)"},
+				"fn() { if (a) { return 1 } else { 2 }; 3 }":        {},
+				"return 1":     {},
+				`throw "a"; 1`: {"1:12: unreachable code (unreachable)"},
+				"fn() { try { return 1 } catch (e) { throw e }; 2 }": {"1:48: unreachable code (unreachable)"},
+				"fn() { try { return 1 } catch (e) { e }; 2 }":       {},
+				"fn() { try { a } finally { return 1 }; 2 }":         {"1:40: unreachable code (unreachable)"},
+			},
+			vet.Condition: {
+				"if (1) { 2 }":                {"1:5: non-boolean condition in if expression (condition)"},
+				`if ("a") { 2 }`:              {"1:5: non-boolean condition in if expression (condition)"},
+				"if (nil) { 2 }":              {"1:5: non-boolean condition in if expression (condition)"},
+				"if (-a) { 2 }":               {"1:5: non-boolean condition in if expression (condition)"},
+				"if (fn() { true }) { 2 }":    {"1:5: non-boolean condition in if expression (condition)"},
+				"if ([true]) { 2 }":           {"1:5: non-boolean condition in if expression (condition)"},
+				"if ({}) { 2 }":               {"1:5: non-boolean condition in if expression (condition)"},
+				"if (true) { 2 }":             {},
+				"if (!a) { 2 }":               {},
+				"if (a < 1) { if (0) { 1 } }": {"1:18: non-boolean condition in if expression (condition)"},
+			},
+		}
+
+		for analyzer, inputs := range cases {
+			Context("when running "+analyzer.Name, func() {
+				for input, expected := range inputs {
+					Context("when checking "+input, func() {
+						It("reports diagnostics", func() {
+							Expect(check(input, analyzer)).To(Equal(expected))
+						})
+					})
+				}
+			})
+		}
+	})
+
+	Describe(".Source", func() {
+		input := "let a = 1;\nlet f = fn(a) { return 1; a };\nif (1) { f }"
+
+		It("runs all registered analyzers sorted by position", func() {
+			Expect(check(input)).To(Equal([]string{
+				"1:5: a declared and not used (unused)",
+				"2:12: declaration of a shadows declaration at 1:5 (shadow)",
+				"2:27: unreachable code (unreachable)",
+				"3:5: non-boolean condition in if expression (condition)",
+			}))
+		})
+
+		It("skips analyzers disabled by a directive", func() {
+			Expect(check("// vet:disable unused shadow\n/* vet:disable condition */\n" + input)).To(Equal([]string{
+				"4:27: unreachable code (unreachable)",
+			}))
+		})
+
+		It("skips all analyzers when directive has no names", func() {
+			Expect(check(input + " // vet:disable")).To(BeEmpty())
+		})
+
+		It("returns parsing errors", func() {
+			_, err := vet.Source([]byte("let = 1"))
+			Expect(err).To(MatchError(parser.ErrInvalidToken))
+		})
+	})
+
+	Describe(".Register", func() {
+		It("adds a custom analyzer", func() {
+			analyzer := &vet.Analyzer{
+				Name: "calls",
+				Doc:  "reports every call",
+				Run: func(pass *vet.Pass) {
+					ast.Inspect(pass.Program, func(node ast.Node) bool {
+						if call, ok := node.(*ast.CallExpression); ok {
+							pass.Report(call, "call")
+						}
+
+						return true
+					})
+				},
+			}
+
+			vet.Register(analyzer)
+			DeferCleanup(func() { vet.Unregister(analyzer.Name) })
+
+			registered, ok := vet.Lookup("calls")
+			Expect(ok).To(BeTrue())
+			Expect(registered).To(Equal(analyzer))
+			Expect(lo.Map(vet.Analyzers(), func(a *vet.Analyzer, _ int) string { return a.Name })).To(Equal([]string{
+				"calls", "condition", "shadow", "unreachable", "unused",
+			}))
+			Expect(check("f(1)")).To(Equal([]string{"1:1: call (calls)"}))
+		})
+	})
+})